@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithProgress` download option to report byte-level progress of artefact downloads, with terminal progress bar and logging reporters
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `DownloadAllJobArtefactsWithReport` returning a `DownloadReport` describing the outcome of each artefact download
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Sandbox artefact destinations to prevent path traversal, escaping symbolic links and Windows reserved names
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithCollisionPolicy` to control what happens when several artefacts are downloaded to the same location
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithManifest` to record downloaded artefacts in a JSON manifest and a `sha256sum`-compatible file, and `VerifyDirectoryAgainstManifest` to check them offline
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithArchive` to download job artefacts directly into a zip or tar.gz archive along with their manifest
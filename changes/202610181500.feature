@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `IArtefactSink` and `WithSink` so that artefacts can be stored in a directory, any filesystem, a store, an archive or custom writers
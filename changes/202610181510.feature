@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `NewArtefactManagerWithStreamingContent` to stream artefact content and verify hashes in a single pass
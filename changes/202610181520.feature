@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Support algorithm-prefixed artefact hashes (MD5, SHA1, SHA256, blake2b256, SHA512) and a minimum accepted hashing algorithm. Hashes which are not prefixed are identified by their length, 64-character ones being considered SHA256
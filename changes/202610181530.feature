@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithRetryPolicy` to download artefacts again, with backoff, when their transfer fails transiently or their content does not match the expected size or hash; the number of attempts is reported
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `IterateJobArtefacts` and `ListJobArtefactManagers` to iterate over the typed artefact managers of a job, resolving links as needed
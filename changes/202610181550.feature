@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `FollowJobArtefacts` to download new or changed artefacts while a job runs, with a pluggable job completion check and a final synchronisation
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithExtractArchives` to extract zip and tar.gz artefacts once verified, protecting against path traversal and decompression bombs (see `WithExtractionLimits`)
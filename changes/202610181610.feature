@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithPreserveAttributes` to apply the modification time and permissions stated in artefacts extra metadata to downloaded files (see `WithAttributeMetadataKeys`). Attributes are not applied by default
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `WithRateLimit` download option limiting the bandwidth shared by all artefact downloads
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `PlanJobArtefactsDownload` listing where artefacts would be downloaded and how much disk space they need without downloading them
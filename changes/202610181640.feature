@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `NewArtefactCache` and `WithCache` so that artefacts identical across jobs are retrieved from a content-addressable cache rather than fetched again, and `WithCacheHardLinks` to store cached artefacts as hard links rather than copies
@@ -0,0 +1 @@
+:sparkles: `[uploader]` Added an uploader for job input files which uploads the content of a directory concurrently, verifies the hashes reported by the service and returns a report
@@ -0,0 +1 @@
+:sparkles: `[artefacts]` Added `DownloadArtefactsForJobs` to download the artefacts of several jobs concurrently into their own directory with a report for each job
@@ -0,0 +1 @@
+:sparkles: `[messages]` Added a JSON formatter emitting one structured record per message, selectable using `NewJSONMessageFormatter` with `NewMessageLoggerFactoryWithFormatter`
@@ -0,0 +1 @@
+:sparkles: `[messages]` Added `NewTemplateMessageFormatter` to lay messages out using a `text/template` layout with helpers for formatting times, relative times, padding and case
@@ -0,0 +1 @@
+:sparkles: `[messages]` Added a minimum severity filter to message loggers and their factory, with an optional summary of the messages suppressed
@@ -0,0 +1 @@
+:sparkles: `[messages]` Added `WithColour` and `WithTerminalColour` formatting options colouring the severity and source of messages, and ANSI escape codes are now removed from logs written to files by `logging.NewStandardClientLogger`
@@ -0,0 +1 @@
+:sparkles: `[messages]` Added `WithBufferSize` and `WithLosslessPrinting` options so that asynchronous message loggers can wait for space in their buffer rather than drop messages, `MessageLoggerFactory.SetBufferSize`, `GetBufferSize` and `SetLosslessPrinting`, and `IMessageLoggerWithStatistics` whose `GetStatistics` reports how many messages were suppressed, dropped or delayed
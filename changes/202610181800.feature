@@ -0,0 +1 @@
+:sparkles: `[diagnostics]` Added a parser extracting GCC, Clang and Arm Compiler diagnostics (file, line, column, severity, code and text) from job messages, with configurable mapping of service workspace paths onto local paths
@@ -0,0 +1,241 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+	"github.com/ARM-software/golang-utils/utils/safeio"
+)
+
+const archiveEntryPermissions = 0644
+
+// ArchiveFormat describes the format of an archive artefacts can be downloaded into.
+type ArchiveFormat int
+
+const (
+	// ArchiveFormatNone states that artefacts are not archived but stored as individual files.
+	ArchiveFormatNone ArchiveFormat = iota
+	// ArchiveFormatZip states that artefacts are stored in a zip archive.
+	ArchiveFormatZip
+	// ArchiveFormatTarGz states that artefacts are stored in a gzip-compressed tarball.
+	ArchiveFormatTarGz
+)
+
+func (f ArchiveFormat) String() string {
+	switch f {
+	case ArchiveFormatZip:
+		return "zip"
+	case ArchiveFormatTarGz:
+		return "tar.gz"
+	default:
+		return "none"
+	}
+}
+
+// Extension returns the file extension usually used for archives of this format.
+func (f ArchiveFormat) Extension() string {
+	switch f {
+	case ArchiveFormatZip:
+		return ".zip"
+	case ArchiveFormatTarGz:
+		return ".tar.gz"
+	default:
+		return ""
+	}
+}
+
+// archiveSink streams artefacts into an archive. Artefacts are staged in temporary files until verified so that only valid artefacts are added.
+type archiveSink struct {
+	mu         sync.Mutex
+	path       string
+	file       filesystem.File
+	zipWriter  *zip.Writer
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+}
+
+// NewArchiveSink returns a sink storing artefacts in an archive of the given format at archivePath.
+// Entry paths correspond to the artefacts' relative paths. The archive is only complete once the sink is closed.
+func NewArchiveSink(archivePath string, format ArchiveFormat) (sink IArtefactSink, err error) {
+	if format != ArchiveFormatZip && format != ArchiveFormatTarGz {
+		err = commonerrors.Newf(commonerrors.ErrUnsupported, "unsupported archive format [%v]", format)
+		return
+	}
+	err = filesystem.MkDir(filepath.Dir(archivePath))
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed creating the directory of archive [%v]", archivePath)
+		return
+	}
+	file, err := filesystem.CreateFile(archivePath)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not create archive [%v]", archivePath)
+		return
+	}
+	s := &archiveSink{path: archivePath, file: file}
+	if format == ArchiveFormatZip {
+		s.zipWriter = zip.NewWriter(file)
+	} else {
+		s.gzipWriter = gzip.NewWriter(file)
+		s.tarWriter = tar.NewWriter(s.gzipWriter)
+	}
+	sink = s
+	return
+}
+
+func (s *archiveSink) Location(relativePath string) string {
+	return path.Clean(relativePath)
+}
+
+func (s *archiveSink) Create(ctx context.Context, relativePath string) (w IArtefactWriter, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	entryName, err := sanitiseRelativePath(relativePath)
+	if err != nil {
+		return
+	}
+	if entryName == "" {
+		err = commonerrors.UndefinedVariable("artefact path")
+		return
+	}
+	staged, err := filesystem.GetGlobalFileSystem().TempFileInTempDir("artefact-staging-")
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not create a staging location for artefact [%v]", relativePath)
+		return
+	}
+	w = &archiveArtefactWriter{sink: s, entryName: filepath.ToSlash(entryName), staged: staged}
+	return
+}
+
+// addEntry adds an entry to the archive. entryName must be a slash-separated relative path.
+func (s *archiveSink) addEntry(ctx context.Context, entryName string, size int64, attributes *artefactAttributes, content io.Reader) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	modTime := time.Now()
+	mode := os.FileMode(archiveEntryPermissions)
+	if attributes != nil {
+		if !attributes.modTime.IsZero() {
+			modTime = attributes.modTime
+		}
+		if attributes.hasMode {
+			mode = attributes.mode
+		}
+	}
+	var entry io.Writer
+	if s.zipWriter != nil {
+		header := &zip.FileHeader{
+			Name:     entryName,
+			Method:   zip.Deflate,
+			Modified: modTime,
+		}
+		header.SetMode(mode)
+		entry, err = s.zipWriter.CreateHeader(header)
+	} else {
+		err = s.tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     entryName,
+			Size:     size,
+			Mode:     int64(mode),
+			ModTime:  modTime,
+		})
+		entry = s.tarWriter
+	}
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not add entry [%v] to archive [%v]", entryName, s.path)
+		return
+	}
+	_, err = safeio.CopyDataWithContext(ctx, content, entry)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not write entry [%v] to archive [%v]", entryName, s.path)
+	}
+	return
+}
+
+// Close finalises the archive. The archive is removed if it could not be completed.
+func (s *archiveSink) Close(context.Context) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.zipWriter != nil {
+		err = s.zipWriter.Close()
+	} else {
+		err = s.tarWriter.Close()
+		if err == nil {
+			err = s.gzipWriter.Close()
+		}
+	}
+	closeErr := s.file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = filesystem.Rm(s.path)
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not finalise archive [%v]", s.path)
+	}
+	return
+}
+
+// archiveArtefactWriter stages an artefact and adds it to the archive once committed.
+type archiveArtefactWriter struct {
+	sink       *archiveSink
+	entryName  string
+	staged     filesystem.File
+	attributes *artefactAttributes
+	done       bool
+}
+
+func (w *archiveArtefactWriter) setAttributes(attributes *artefactAttributes) {
+	w.attributes = attributes
+}
+
+func (w *archiveArtefactWriter) Write(p []byte) (int, error) {
+	return w.staged.Write(p)
+}
+
+func (w *archiveArtefactWriter) Commit(ctx context.Context) (err error) {
+	if w.done {
+		return
+	}
+	w.done = true
+	defer func() { _ = filesystem.Rm(w.staged.Name()) }()
+	size, err := w.staged.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = w.staged.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = w.staged.Close()
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not read staged artefact [%v]", w.entryName)
+		return
+	}
+	err = w.sink.addEntry(ctx, w.entryName, size, w.attributes, w.staged)
+	closeErr := w.staged.Close()
+	if err == nil && closeErr != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, closeErr, "could not close staged artefact [%v]", w.entryName)
+	}
+	return
+}
+
+func (w *archiveArtefactWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	_ = w.staged.Close()
+	return filesystem.Rm(w.staged.Name())
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+func readTestArchive(t *testing.T, archivePath string, format ArchiveFormat) map[string][]byte {
+	t.Helper()
+	entries := map[string][]byte{}
+	if format == ArchiveFormatZip {
+		r, err := zip.OpenReader(archivePath)
+		require.NoError(t, err)
+		defer func() { _ = r.Close() }()
+		for _, f := range r.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			_ = rc.Close()
+			entries[f.Name] = content
+		}
+		return entries
+	}
+	f, err := filesystem.GenericOpen(archivePath)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	r := tar.NewReader(gz)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		entries[header.Name] = content
+	}
+	return entries
+}
+
+func TestDownloadIntoArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	var artefacts []*testArtefact
+	for i := 0; i < 3; i++ {
+		a := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+		a.extraMetadata = map[string]string{relativePathKey: fmt.Sprintf("dir%d/sub", i)}
+		artefacts = append(artefacts, a)
+	}
+	m := newTestArtefactsManager(t, artefacts, false)
+
+	for _, format := range []ArchiveFormat{ArchiveFormatZip, ArchiveFormatTarGz} {
+		t.Run(format.String(), func(t *testing.T) {
+			out := t.TempDir()
+			jobName := faker.Word()
+			report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), jobName, out, WithMaintainStructure(true), WithArchive(format, ""))
+			require.NoError(t, err)
+			archivePath := filepath.Join(out, jobName+format.Extension())
+			require.FileExists(t, archivePath)
+			files, err := filesystem.Ls(out)
+			require.NoError(t, err)
+			assert.Len(t, files, 1)
+
+			entries := readTestArchive(t, archivePath, format)
+			assert.Len(t, entries, len(artefacts)+2)
+			require.Contains(t, entries, DefaultManifestFileName)
+			require.Contains(t, entries, DefaultChecksumFileName)
+			manifest := Manifest{}
+			require.NoError(t, json.Unmarshal(entries[DefaultManifestFileName], &manifest))
+			assert.Equal(t, jobName, manifest.Job)
+			require.Len(t, manifest.Artefacts, len(artefacts))
+			for i := range artefacts {
+				expectedEntry := fmt.Sprintf("dir%d/sub/%v", i, artefacts[i].name)
+				assert.Equal(t, expectedEntry, report.Artefacts[i].Destination)
+				assert.Equal(t, expectedEntry, manifest.Artefacts[i].RelativePath)
+				expectedContent, err := filesystem.ReadFile(artefacts[i].path)
+				require.NoError(t, err)
+				require.Contains(t, entries, expectedEntry)
+				assert.Equal(t, expectedContent, entries[expectedEntry])
+				assert.Contains(t, string(entries[DefaultChecksumFileName]), checksumLine(manifest.Artefacts[i].Hash, expectedEntry))
+			}
+		})
+	}
+	t.Run("with archive path", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), faker.Word(), "artefacts.zip")
+		_, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithArchive(ArchiveFormatZip, archivePath))
+		require.NoError(t, err)
+		entries := readTestArchive(t, archivePath, ArchiveFormatZip)
+		for i := range artefacts {
+			assert.Contains(t, entries, artefacts[i].name)
+		}
+	})
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithArchive(ArchiveFormat(-1), ""))
+		errortest.AssertError(t, err, commonerrors.ErrUnsupported)
+	})
+}
@@ -8,19 +8,23 @@ package artefacts
 import (
 	"context"
 	"fmt"
+	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ARM-software/embedded-development-services-client-utils/utils/api"
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
 	paginationUtils "github.com/ARM-software/embedded-development-services-client-utils/utils/pagination"
 	"github.com/ARM-software/embedded-development-services-client/client"
 	"github.com/ARM-software/golang-utils/utils/collection/pagination"
 	"github.com/ARM-software/golang-utils/utils/commonerrors"
 	"github.com/ARM-software/golang-utils/utils/filesystem"
-	"github.com/ARM-software/golang-utils/utils/hashing"
 	"github.com/ARM-software/golang-utils/utils/parallelisation"
 	"github.com/ARM-software/golang-utils/utils/reflection"
 	"github.com/ARM-software/golang-utils/utils/safeio"
@@ -37,6 +41,8 @@ type (
 	GetArtefactManagerFunc[M IManager] = func(ctx context.Context, job, artefact string) (M, *http.Response, error)
 	// GetArtefactContentFunc is a function able to return the content of any artefact managers.
 	GetArtefactContentFunc = func(ctx context.Context, job, artefactID string) (*os.File, *http.Response, error)
+	// GetArtefactContentStreamFunc is a function able to return the content of any artefact managers as a stream e.g. the body of the HTTP response.
+	GetArtefactContentStreamFunc = func(ctx context.Context, job, artefactID string) (io.ReadCloser, *http.Response, error)
 )
 
 func determineArtefactDestination[M IManager](outputDir string, maintainTree bool, item M) (artefactFileName string, destinationDir string, err error) {
@@ -53,9 +59,13 @@ func determineArtefactDestination[M IManager](outputDir string, maintainTree boo
 	if item.HasTitle() {
 		rawFileName = item.GetTitle()
 	}
-	artefactFileName = rawFileName
-	if unescapedName, err := url.PathUnescape(rawFileName); err == nil {
-		artefactFileName = unescapedName
+	unescapedFileName := rawFileName
+	if unescapedName, subErr := url.PathUnescape(rawFileName); subErr == nil {
+		unescapedFileName = unescapedName
+	}
+	artefactFileName, err = sanitiseFileName(unescapedFileName)
+	if err != nil {
+		return
 	}
 	destinationDir = filepath.Clean(outputDir)
 	if !maintainTree {
@@ -68,15 +78,139 @@ func determineArtefactDestination[M IManager](outputDir string, maintainTree boo
 		if !ok {
 			return
 		}
-		treePath = strings.TrimSpace(treePath)
-		if strings.HasSuffix(treePath, rawFileName) || strings.HasSuffix(treePath, artefactFileName) {
-			treePath = filepath.Dir(treePath)
+		treePath = normaliseSeparators(strings.TrimSpace(treePath))
+		if strings.HasSuffix(treePath, normaliseSeparators(rawFileName)) || strings.HasSuffix(treePath, normaliseSeparators(unescapedFileName)) {
+			treePath = path.Dir(treePath)
+		}
+		treePath, err = sanitiseRelativePath(treePath)
+		if err != nil {
+			return
 		}
 		destinationDir = filepath.Clean(filepath.Join(outputDir, treePath))
+		if !transfer.IsWithinDirectory(outputDir, destinationDir) {
+			err = commonerrors.Newf(commonerrors.ErrInvalid, "artefact destination [%v] is outside the output directory [%v]", destinationDir, outputDir)
+		}
+	}
+	return
+}
+
+// determineArtefactRelativePath returns the slash-separated path of an artefact relative to the root of the destination.
+func determineArtefactRelativePath[M IManager](maintainTree bool, item M) (relativePath string, err error) {
+	artefactFileName, destinationDir, err := determineArtefactDestination("", maintainTree, item)
+	if err != nil {
+		return
+	}
+	if reflection.IsEmpty(artefactFileName) {
+		err = commonerrors.UndefinedVariable("artefact filename")
+		return
+	}
+	relativePath = filepath.ToSlash(filepath.Join(destinationDir, artefactFileName))
+	return
+}
+
+// downloadSession holds the state shared by all the artefact downloads performed as part of a single request.
+type downloadSession struct {
+	jobName      string
+	options      *DownloadOptions
+	progress     *progressTracker
+	report       *DownloadReport
+	destinations *destinationRegistry
+	manifest     *manifestRecorder
+	rateLimiter  *rateLimiter
+	sink         IArtefactSink
+	ownsSink     bool
+	// provisional states that failures should not be recorded as artefacts will be downloaded again later e.g. while following a running job.
+	provisional bool
+}
+
+func newDownloadSession(jobName string, options *DownloadOptions) *downloadSession {
+	if options == nil {
+		options = NewDownloadOptions()
+	}
+	session := &downloadSession{
+		jobName:      jobName,
+		options:      options,
+		progress:     newProgressTracker(jobName, options.Progress),
+		report:       newDownloadReport(jobName),
+		destinations: newDestinationRegistry(options.CollisionPolicy),
+		rateLimiter:  newRateLimiter(options.RateLimit),
+	}
+	if options.GenerateManifest || options.ArchiveFormat != ArchiveFormatNone {
+		session.manifest = newManifestRecorder(jobName)
+		session.destinations.reserve(DefaultManifestFileName, "artefact manifest")
+		session.destinations.reserve(DefaultChecksumFileName, "artefact checksum file")
+	}
+	return session
+}
+
+func (s *downloadSession) record(result ArtefactReport, duration time.Duration, err error) {
+	if err != nil && s.provisional {
+		return
+	}
+	result.Duration = duration
+	if err == nil {
+		if result.Status != DownloadStatusSkipped {
+			result.Status = DownloadStatusDownloaded
+		}
+	} else {
+		result.Status = DownloadStatusFailed
+		result.Err = err
+	}
+	s.report.record(result)
+}
+
+// openSink determines where artefacts should be stored: the sink provided in the options, an archive or outputDirectory.
+func (s *downloadSession) openSink(ctx context.Context, outputDirectory string) (err error) {
+	if s.options.Sink != nil {
+		s.sink = s.options.Sink
+		if preparedSink, ok := s.sink.(iPreparedSink); ok {
+			err = preparedSink.prepare(ctx)
+		}
+		return
+	}
+	err = filesystem.MkDir(outputDirectory)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed creating the output directory [%v] for job artefacts", outputDirectory)
+		return
+	}
+	if s.options.ArchiveFormat == ArchiveFormatNone {
+		s.sink = NewDirectorySink(outputDirectory)
+		return
+	}
+	archivePath := s.options.ArchivePath
+	if reflection.IsEmpty(archivePath) {
+		archivePath = filepath.Join(outputDirectory, s.jobName+s.options.ArchiveFormat.Extension())
+	}
+	s.sink, err = NewArchiveSink(archivePath, s.options.ArchiveFormat)
+	s.ownsSink = true
+	return
+}
+
+// closeSink writes the manifest, if any, and finalises the sink if it was created for this session.
+func (s *downloadSession) closeSink(ctx context.Context) (err error) {
+	if s.manifest != nil {
+		err = s.manifest.write(ctx, s.sink)
+	}
+	if s.ownsSink {
+		err = collateErrors(err, s.sink.Close(ctx))
 	}
 	return
 }
 
+// flushCache records how recently cached artefacts were used, if a cache is used. As this only affects which artefacts are evicted first, failures are only logged.
+func (s *downloadSession) flushCache(ctx context.Context) {
+	err := s.options.Cache.Flush(ctx)
+	if err != nil && !commonerrors.Any(err, commonerrors.ErrCancelled, commonerrors.ErrTimeout) && s.options.Logger != nil {
+		s.options.Logger.LogError(err)
+	}
+}
+
+// fail records the failure of an artefact download which could not even be started.
+func (s *downloadSession) fail(artefactName string, err error) {
+	s.progress.newArtefactTracker(artefactName).done(err)
+	s.record(ArtefactReport{Name: artefactName}, 0, err)
+}
+
 type ArtefactManager[
 	M IManager,
 	D ILinkData,
@@ -85,6 +219,7 @@ type ArtefactManager[
 ] struct {
 	getArtefactManagerFunc            GetArtefactManagerFunc[M]
 	getArtefactContentFunc            GetArtefactContentFunc
+	getArtefactContentStreamFunc      GetArtefactContentStreamFunc
 	getArtefactManagersFirstPageFunc  GetArtefactManagersFirstPageFunc[D, L, C]
 	getArtefactManagersFollowLinkFunc FollowLinkToArtefactManagersPageFunc[D, L, C]
 }
@@ -107,120 +242,364 @@ func NewArtefactManager[
 		getArtefactManagersFollowLinkFunc: getArtefactsManagersPage,
 	}
 }
-func (m *ArtefactManager[M, D, L, C]) DownloadJobArtefact(ctx context.Context, jobName string, outputDirectory string, artefactManager M) (err error) {
-	return m.DownloadJobArtefactWithTree(ctx, jobName, false, outputDirectory, artefactManager)
+
+// NewArtefactManagerWithStreamingContent returns an artefact manager which streams artefacts' content rather than relying on temporary files.
+func NewArtefactManagerWithStreamingContent[
+	M IManager,
+	D ILinkData,
+	L ILinks[D],
+	C ICollection[D, L],
+](
+	getArtefactManagersFirstPage GetArtefactManagersFirstPageFunc[D, L, C],
+	getArtefactsManagersPage FollowLinkToArtefactManagersPageFunc[D, L, C],
+	getArtefactManager GetArtefactManagerFunc[M],
+	getOutputArtefactStream GetArtefactContentStreamFunc) IArtefactManager[M, D] {
+	return &ArtefactManager[M, D, L, C]{
+		getArtefactManagerFunc:            getArtefactManager,
+		getArtefactContentStreamFunc:      getOutputArtefactStream,
+		getArtefactManagersFirstPageFunc:  getArtefactManagersFirstPage,
+		getArtefactManagersFollowLinkFunc: getArtefactsManagersPage,
+	}
+}
+
+func (m *ArtefactManager[M, D, L, C]) hasContentFunc() bool {
+	return m.getArtefactContentFunc != nil || m.getArtefactContentStreamFunc != nil
 }
 
-func (m *ArtefactManager[M, D, L, C]) DownloadJobArtefactWithTree(ctx context.Context, jobName string, maintainTreeLocation bool, outputDirectory string, artefactManager M) (err error) {
+// openArtefactContent returns the content of an artefact as a stream, whichever way the content is provided.
+func (m *ArtefactManager[M, D, L, C]) openArtefactContent(ctx context.Context, jobName, artefactName, artefactFilename string) (content io.ReadCloser, err error) {
+	errorContext := fmt.Sprintf("cannot fetch generated artefact [%v]", artefactFilename)
+	if m.getArtefactContentStreamFunc == nil {
+		file, subErr := api.CallAndCheckSuccess[os.File](ctx, errorContext, func(fCtx context.Context) (*os.File, *http.Response, error) {
+			return m.getArtefactContentFunc(fCtx, jobName, artefactName)
+		})
+		if file != nil {
+			content = file
+		}
+		err = subErr
+		return
+	}
 	err = parallelisation.DetermineContextError(ctx)
 	if err != nil {
 		return
 	}
-	if m.getArtefactManagerFunc == nil || m.getArtefactContentFunc == nil {
-		err = commonerrors.New(commonerrors.ErrUndefined, "function to retrieve an artefact manager was not properly defined")
-		return
+	stream, resp, apiErr := m.getArtefactContentStreamFunc(ctx, jobName, artefactName)
+	response := &responseStream{stream: stream, response: resp}
+	err = api.CheckAPICallSuccess(ctx, errorContext, resp, apiErr)
+	if err == nil && apiErr != nil {
+		err = commonerrors.WrapError(commonerrors.ErrUnexpected, apiErr, errorContext)
+	}
+	if err == nil && stream == nil {
+		err = commonerrors.Newf(commonerrors.ErrEmpty, "%v: no content was returned", errorContext)
 	}
-
-	err = filesystem.MkDir(outputDirectory)
 	if err != nil {
-		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed creating the output directory [%v] for job artefact", outputDirectory)
+		_ = response.Close()
 		return
 	}
+	content = response
+	return
+}
+
+// responseStream is the content of an artefact which also releases the HTTP response it originates from when closed.
+type responseStream struct {
+	stream   io.ReadCloser
+	response *http.Response
+}
+
+func (r *responseStream) Read(p []byte) (int, error) {
+	return r.stream.Read(p)
+}
+
+func (r *responseStream) Close() (err error) {
+	if r.stream != nil {
+		err = r.stream.Close()
+	}
+	if r.response != nil && r.response.Body != nil {
+		_ = r.response.Body.Close()
+	}
+	return
+}
+
+func (m *ArtefactManager[M, D, L, C]) DownloadJobArtefact(ctx context.Context, jobName string, outputDirectory string, artefactManager M) (err error) {
+	return m.DownloadJobArtefactWithTree(ctx, jobName, false, outputDirectory, artefactManager)
+}
+
+func (m *ArtefactManager[M, D, L, C]) DownloadJobArtefactWithTree(ctx context.Context, jobName string, maintainTreeLocation bool, outputDirectory string, artefactManager M) error {
+	session := newDownloadSession(jobName, NewDownloadOptions(WithMaintainStructure(maintainTreeLocation)))
+	session.sink = NewDirectorySink(outputDirectory)
+	return m.downloadJobArtefact(ctx, session, artefactManager)
+}
+
+func (m *ArtefactManager[M, D, L, C]) downloadJobArtefact(ctx context.Context, session *downloadSession, artefactManager M) (err error) {
+	var artefactManagerName string
+	if any(artefactManager) != nil {
+		artefactManagerName = artefactManager.GetName()
+	}
+	result := ArtefactReport{Name: artefactManagerName}
+	progress := session.progress.newArtefactTracker(artefactManagerName)
+	start := time.Now()
+	err = m.transferJobArtefact(ctx, session, artefactManager, progress, &result)
+	// archives deleted once extracted are not listed as they cannot be verified.
+	if err == nil && session.manifest != nil && result.Destination != "" {
+		session.manifest.add(newManifestEntry(artefactManager, &result))
+	}
+	progress.done(err)
+	session.record(result, time.Since(start), err)
+	return
+}
 
-	fileHasher, err := filesystem.NewFileHash(hashing.HashSha256)
+func (m *ArtefactManager[M, D, L, C]) transferJobArtefact(ctx context.Context, session *downloadSession, artefactManager M, progress *artefactProgressTracker, result *ArtefactReport) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
 	if err != nil {
 		return
 	}
-	if any(artefactManager) == nil {
-		err = commonerrors.UndefinedVariable("artefact manager")
+	if m.getArtefactManagerFunc == nil || !m.hasContentFunc() {
+		err = commonerrors.New(commonerrors.ErrUndefined, "function to retrieve an artefact manager was not properly defined")
 		return
 	}
-
-	artefactManagerName := artefactManager.GetName()
-	if artefactManagerName == "" {
-		err = commonerrors.UndefinedVariable("artefact name")
+	if session.sink == nil {
+		err = commonerrors.UndefinedVariable("artefact sink")
 		return
 	}
 
-	expectedSizePtr, ok := artefactManager.GetSizeOk()
-	if !ok {
-		err = commonerrors.Newf(commonerrors.ErrUndefined, "could not fetch artefact's size from artefact's manager [%v]", artefactManagerName)
+	planned, expectedHash, err := planJobArtefact(session, artefactManager)
+	if err != nil {
 		return
 	}
-	expectedSize := *expectedSizePtr
+	artefactManagerName := planned.Name
+	expectedSize := planned.Size
+	result.RelativePath = planned.RelativePath
+	result.Destination = planned.Destination
+	result.OriginalDestination = planned.OriginalDestination
 
-	expectedHashPtr, ok := artefactManager.GetHashOk()
-	if !ok {
-		err = commonerrors.Newf(commonerrors.ErrUndefined, "could not fetch artefact's hash from artefact's manager [%v]", artefactManagerName)
-		return
+	var attributes *artefactAttributes
+	if session.options.PreserveAttributes && artefactManager.HasExtraMetadata() {
+		var subErr error
+		attributes, subErr = determineArtefactAttributes(artefactManager.GetExtraMetadata(), session.options.ModificationTimeKey, session.options.FileModeKey)
+		// invalid attributes should not prevent the artefact from being downloaded.
+		if subErr != nil {
+			attributes = nil
+			if session.options.Logger != nil {
+				session.options.Logger.LogError(fmt.Sprintf("file attributes of artefact [%v] cannot be applied: %v", artefactManagerName, subErr))
+			}
+		}
 	}
-	expectedHash := *expectedHashPtr
 
-	artefactFilename, artefactDestDir, err := determineArtefactDestination(outputDirectory, maintainTreeLocation, artefactManager)
+	cache := session.options.Cache
+	cachedPath, err := cache.lookup(ctx, &expectedHash)
 	if err != nil {
-		return
+		// the artefact can still be fetched if the cache cannot be used.
+		cachedPath = ""
+		if session.options.Logger != nil {
+			session.options.Logger.LogError(fmt.Sprintf("artefact cache cannot be used for artefact [%v]: %v", artefactManagerName, err))
+		}
 	}
-	if reflection.IsEmpty(artefactFilename) {
-		err = commonerrors.UndefinedVariable("artefact filename")
+	// hard links share their content and attributes with the cache entry so they are only used if the artefact is stored as is.
+	if linker, ok := session.sink.(iArtefactLinker); ok && session.options.LinkCachedArtefacts && cachedPath != "" && attributes.isEmpty() && planned.ExtractedTo == "" {
+		// as the content is not copied, the cache entry is verified beforehand.
+		if verifyErr := cache.verify(ctx, cachedPath, expectedSize, &expectedHash); verifyErr != nil {
+			cache.discard(&expectedHash)
+			cachedPath = ""
+		} else if linkErr := linker.link(ctx, result.RelativePath, cache.fs(), cachedPath); linkErr == nil {
+			progress.start(expectedSize)
+			result.Size = expectedSize
+			result.Hash = expectedHash.Digest
+			result.HashAlgorithm = expectedHash.Algorithm
+			result.FromCache = true
+			err = nil
+			return
+		}
+	}
+
+	// transient is set by every attempt so that only failures which may not happen again are retried e.g. a connection reset or a corrupted transfer.
+	transient := false
+	err = transfer.RetryIf(ctx, session.options.RetryPolicy, session.options.Logger, func() error {
+		result.Attempts++
+		var subErr error
+		transient, subErr = m.attemptJobArtefactTransfer(ctx, session, artefactManagerName, expectedSize, &expectedHash, attributes, cachedPath, progress, result)
+		if subErr != nil && transient && cachedPath != "" && parallelisation.DetermineContextError(ctx) == nil {
+			// the cached copy cannot be used e.g. because it is corrupted: it is discarded and the artefact is fetched instead.
+			// other failures e.g. the destination cannot be written are returned as they are, as they would also happen when fetching the artefact.
+			cache.discard(&expectedHash)
+			cachedPath = ""
+			transient, subErr = m.attemptJobArtefactTransfer(ctx, session, artefactManagerName, expectedSize, &expectedHash, attributes, cachedPath, progress, result)
+		}
+		return subErr
+	}, fmt.Sprintf("Downloading artefact [%v] again...", artefactManagerName), func(error) bool {
+		return transient
+	})
+	return
+}
+
+// attemptJobArtefactTransfer fetches the content of an artefact, stores it in the session's sink and verifies it. If cachedPath is provided, the content is copied from the artefact cache instead.
+// transient states whether the failure, if any, may not happen if the artefact is fetched again i.e. whether the content read, from the service or from the cache, was at fault.
+func (m *ArtefactManager[M, D, L, C]) attemptJobArtefactTransfer(ctx context.Context, session *downloadSession, artefactManagerName string, expectedSize int64, expectedHash *transfer.Hash, attributes *artefactAttributes, cachedPath string, progress *artefactProgressTracker, result *ArtefactReport) (transient bool, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
 		return
 	}
-	err = filesystem.MkDir(artefactDestDir)
+	hasher, err := newHashingWriter(ctx, expectedHash.Algorithm)
 	if err != nil {
-		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed creating the output directory [%v] for job artefact", artefactDestDir)
 		return
 	}
-	artefact, err := api.CallAndCheckSuccess[os.File](ctx, fmt.Sprintf("cannot fetch generated artefact [%v]", artefactFilename), func(fCtx context.Context) (*os.File, *http.Response, error) {
-		return m.getArtefactContentFunc(fCtx, jobName, artefactManagerName)
-	})
+	defer func() { _, _ = hasher.Sum() }()
+	artefactFilename := path.Base(result.RelativePath)
+	archiveFormat, extractionDirectory := ArchiveFormatNone, ""
+	if session.options.ExtractArchives {
+		archiveFormat, extractionDirectory = determineExtractionDirectory(result.RelativePath)
+	}
+	// archives to extract are also staged locally as the sink may not be readable.
+	var staged filesystem.File
+	if archiveFormat != ArchiveFormatNone {
+		staged, err = filesystem.GetGlobalFileSystem().TempFileInTempDir("artefact-extraction-")
+		if err != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not create a staging location for archive [%v]", artefactFilename)
+			return
+		}
+		defer func() {
+			_ = staged.Close()
+			_ = filesystem.Rm(staged.Name())
+		}()
+	}
+	writers := []io.Writer{progress}
+	if staged != nil {
+		writers = append(writers, staged)
+	}
+	if staged == nil || !session.options.DeleteArchives {
+		destination, subErr := session.sink.Create(ctx, result.RelativePath)
+		if subErr != nil {
+			err = subErr
+			return
+		}
+		defer func() {
+			if err == nil {
+				err = destination.Commit(ctx)
+			} else {
+				_ = destination.Abort()
+			}
+		}()
+		if setter, ok := destination.(iAttributesSetter); ok && !attributes.isEmpty() {
+			setter.setAttributes(attributes)
+		}
+		writers = append(writers, destination)
+	}
+	if cachedPath == "" {
+		cacheWriter, subErr := session.options.Cache.newWriter(ctx, expectedHash, expectedSize)
+		if subErr != nil && session.options.Logger != nil {
+			session.options.Logger.LogError(fmt.Sprintf("artefact [%v] cannot be added to the artefact cache: %v", artefactManagerName, subErr))
+		}
+		if cacheWriter != nil {
+			defer func() {
+				if err != nil {
+					cacheWriter.abort()
+					return
+				}
+				// failing to cache the artefact does not affect the download.
+				if subErr := cacheWriter.commit(); subErr != nil && session.options.Logger != nil {
+					session.options.Logger.LogError(subErr)
+				}
+			}()
+			writers = append(writers, cacheWriter)
+		}
+	}
+	progress.start(expectedSize)
+	var artefact io.ReadCloser
+	// failures to read the content fetched from the service are told apart from failures to store it as only the former may be transient.
+	var source *readErrorRecorder
+	if cachedPath == "" {
+		artefact, err = m.openArtefactContent(ctx, session.jobName, artefactManagerName, artefactFilename)
+		source = &readErrorRecorder{reader: session.rateLimiter.reader(ctx, artefact)}
+	} else {
+		artefact, err = session.options.Cache.fs().GenericOpen(cachedPath)
+		source = &readErrorRecorder{reader: artefact}
+	}
 	defer func() {
 		if artefact != nil {
 			_ = artefact.Close()
 		}
 	}()
 	if err != nil {
+		if cachedPath != "" {
+			transient = true
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not read cached artefact [%v]", artefactFilename)
+			return
+		}
+		transient = transfer.IsTransientError(err)
 		return
 	}
-	destination, err := filesystem.CreateFile(filepath.Join(artefactDestDir, artefactFilename))
-	if err != nil {
-		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not create a location to store generated artefact [%v]", artefactFilename)
-		return
-	}
-	defer func() { _ = destination.Close() }()
 
-	actualSize, err := safeio.CopyDataWithContext(ctx, artefact, destination)
+	// the content is hashed while being copied so that it is only read once.
+	actualSize, err := safeio.CopyDataWithContext(ctx, io.TeeReader(source, hasher), io.MultiWriter(writers...))
+	result.Size = actualSize
 	if err != nil {
+		if source.err != nil && parallelisation.DetermineContextError(ctx) == nil {
+			transient = true
+			if cachedPath != "" {
+				err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not read cached artefact [%v]", artefactFilename)
+				return
+			}
+			// the transfer was interrupted e.g. the connection was reset.
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnavailable, err, "failed to fetch artefact [%v]", artefactFilename)
+			return
+		}
 		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed to copy artefact [%v]", artefactFilename)
 		return
 	}
 	if actualSize == 0 {
+		transient = true
 		err = commonerrors.Newf(commonerrors.ErrEmpty, "problem with artefact [%v]", artefactFilename)
 		return
 	}
 	if actualSize != expectedSize {
+		transient = true
 		err = commonerrors.Newf(commonerrors.ErrCondition, "artefact [%v] size '%v' does not match expected '%v'", artefactFilename, actualSize, expectedSize)
 		return
 	}
 
-	// reset offset for hashing entire contents
-	_, err = destination.Seek(0, 0)
+	actualHash, err := hasher.Sum()
 	if err != nil {
-		err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not reset destination file")
 		return
 	}
-
-	actualHash, err := fileHasher.CalculateWithContext(ctx, destination)
-	if err != nil {
-		err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not calculate hash of destination file")
-	}
-	if actualHash != expectedHash {
-		err = commonerrors.Newf(commonerrors.ErrCondition, "artefact [%v] hash '%v' does not match expected '%v'", artefactFilename, actualHash, expectedHash)
+	if !expectedHash.Matches(actualHash) {
+		transient = true
+		err = commonerrors.Newf(commonerrors.ErrCondition, "artefact [%v] %v hash '%v' does not match expected '%v'", artefactFilename, expectedHash.Algorithm, actualHash, expectedHash.Digest)
 		return
 	}
+	result.Hash = actualHash
+	result.HashAlgorithm = expectedHash.Algorithm
+	result.FromCache = cachedPath != ""
+
+	if staged != nil {
+		err = newArchiveExtractor(session.sink, session.destinations, artefactManagerName, extractionDirectory, session.options.ExtractionLimits).extract(ctx, archiveFormat, staged)
+		if err != nil {
+			err = commonerrors.DescribeCircumstanceAndKeepTypef(err, "could not extract archive [%v]", artefactFilename)
+			return
+		}
+		result.ExtractedTo = session.sink.Location(extractionDirectory)
+		if session.options.DeleteArchives {
+			result.Destination = ""
+			result.OriginalDestination = ""
+		}
+	}
 
 	err = parallelisation.DetermineContextError(ctx)
 	return
+}
 
+// readErrorRecorder records the error, if any, encountered while reading content.
+type readErrorRecorder struct {
+	reader io.Reader
+	err    error
 }
+
+func (r *readErrorRecorder) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	if err != nil && !commonerrors.Any(err, io.EOF) {
+		r.err = err
+	}
+	return
+}
+
 func (m *ArtefactManager[M, D, L, C]) DownloadJobArtefactFromLink(ctx context.Context, jobName string, outputDirectory string, artefactManagerItemLink D) error {
 	return m.DownloadJobArtefactFromLinkWithTree(ctx, jobName, false, outputDirectory, artefactManagerItemLink)
 }
@@ -230,7 +609,20 @@ func (m *ArtefactManager[M, D, L, C]) DownloadJobArtefactFromLinkWithTree(ctx co
 	if err != nil {
 		return
 	}
-	if m.getArtefactManagerFunc == nil || m.getArtefactContentFunc == nil {
+	if m.getArtefactManagerFunc == nil || !m.hasContentFunc() {
+		err = commonerrors.New(commonerrors.ErrUndefined, "function to retrieve an artefact manager was not properly defined")
+		return
+	}
+	artefactManager, err := m.fetchArtefactManager(ctx, jobName, artefactManagerItemLink)
+	if err != nil {
+		return
+	}
+	err = m.DownloadJobArtefactWithTree(ctx, jobName, maintainTreeLocation, outputDirectory, artefactManager)
+	return
+}
+
+func (m *ArtefactManager[M, D, L, C]) fetchArtefactManager(ctx context.Context, jobName string, artefactManagerItemLink D) (artefactManager M, err error) {
+	if m.getArtefactManagerFunc == nil {
 		err = commonerrors.New(commonerrors.ErrUndefined, "function to retrieve an artefact manager was not properly defined")
 		return
 	}
@@ -244,13 +636,9 @@ func (m *ArtefactManager[M, D, L, C]) DownloadJobArtefactFromLinkWithTree(ctx co
 		err = commonerrors.UndefinedVariable("artefact name")
 		return
 	}
-	artefactManager, err := api.GenericCallAndCheckSuccess[M](ctx, fmt.Sprintf("cannot fetch artefact's manager [%v]", artefactManagerName), func(fCtx context.Context) (M, *http.Response, error) {
+	artefactManager, err = api.GenericCallAndCheckSuccess[M](ctx, fmt.Sprintf("cannot fetch artefact's manager [%v]", artefactManagerName), func(fCtx context.Context) (M, *http.Response, error) {
 		return m.getArtefactManagerFunc(fCtx, jobName, artefactManagerName)
 	})
-	if err != nil {
-		return
-	}
-	err = m.DownloadJobArtefactWithTree(ctx, jobName, maintainTreeLocation, outputDirectory, artefactManager)
 	return
 }
 
@@ -336,54 +724,72 @@ func (m *ArtefactManager[M, D, L, C]) DownloadAllJobArtefactsWithTree(ctx contex
 }
 
 func (m *ArtefactManager[M, D, L, C]) DownloadAllJobArtefactsWithOptions(ctx context.Context, jobName string, outputDirectory string, opts ...DownloadOption) (err error) {
+	_, err = m.DownloadAllJobArtefactsWithReport(ctx, jobName, outputDirectory, opts...)
+	return
+}
+
+func (m *ArtefactManager[M, D, L, C]) DownloadAllJobArtefactsWithReport(ctx context.Context, jobName string, outputDirectory string, opts ...DownloadOption) (report *DownloadReport, err error) {
 	err = parallelisation.DetermineContextError(ctx)
 	if err != nil {
 		return
 	}
+	report, err = m.downloadAllJobArtefactsWithReport(ctx, jobName, outputDirectory, NewDownloadOptions(opts...), nil)
+	return
+}
 
-	dlOpts := NewDownloadOptions(opts...)
-	err = filesystem.MkDir(outputDirectory)
-	if err != nil {
-		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed creating the output directory [%v] for job artefacts", outputDirectory)
-		return
-	}
-	paginator, err := m.ListJobArtefacts(ctx, jobName)
+// downloadAllJobArtefactsWithReport downloads all the artefacts of a job. If sharedRateLimiter is provided, it is used instead of a limiter specific to this download so that the rate limit applies to several downloads.
+func (m *ArtefactManager[M, D, L, C]) downloadAllJobArtefactsWithReport(ctx context.Context, jobName string, outputDirectory string, options *DownloadOptions, sharedRateLimiter *rateLimiter) (report *DownloadReport, err error) {
+	session := newDownloadSession(jobName, options)
+	if sharedRateLimiter != nil {
+		session.rateLimiter = sharedRateLimiter
+	}
+	report = session.report
+	start := time.Now()
+	defer func() { report.Duration = time.Since(start) }()
+	err = session.openSink(ctx, outputDirectory)
 	if err != nil {
 		return
 	}
-	stop := paginator.Stop()
-	defer stop()
+	err = m.downloadAllJobArtefacts(ctx, session)
+	err = collateErrors(err, session.closeSink(ctx))
+	session.flushCache(ctx)
+	return
+}
+
+func (m *ArtefactManager[M, D, L, C]) downloadAllJobArtefacts(ctx context.Context, session *downloadSession) error {
+	return m.syncJobArtefacts(ctx, session, nil)
+}
+
+// syncJobArtefacts downloads the artefacts of a job. If downloadedHashes is provided, artefacts whose hash is the same as when they were last downloaded are not downloaded again and downloadedHashes is updated accordingly.
+// If the session is provisional, errors do not stop the synchronisation.
+func (m *ArtefactManager[M, D, L, C]) syncJobArtefacts(ctx context.Context, session *downloadSession, downloadedHashes map[string]string) (err error) {
+	dlOpts := session.options
 	var collatedDownloadErrors []error
-	for {
-		if !paginator.HasNext() {
-			if len(collatedDownloadErrors) > 0 {
-				err = commonerrors.Join(collatedDownloadErrors...)
-			}
-			return
-		}
-		item, subErr := paginator.GetNext()
-		if subErr != nil {
-			err = commonerrors.WrapError(commonerrors.ErrUnexpected, subErr, "failed getting information about job artefacts")
+	for artefact, subErr := range m.iterateJobArtefacts(ctx, session.jobName) {
+		if artefact == nil {
+			err = subErr
 			return
 		}
-		var artefactName string
-		var downloadErr error
-		artefactLink, ok := item.(D)
-		if ok {
-			artefactName = artefactLink.GetName()
-			downloadErr = m.DownloadJobArtefactFromLinkWithTree(ctx, jobName, dlOpts.MaintainTreeStructure, outputDirectory, artefactLink)
-		} else {
-			artefactManager, isManager := item.(M)
-			if isManager {
-				artefactName = artefactManager.GetName()
-				downloadErr = m.DownloadJobArtefactWithTree(ctx, jobName, dlOpts.MaintainTreeStructure, outputDirectory, artefactManager)
-			} else {
-				downloadErr = commonerrors.New(commonerrors.ErrMarshalling, "the type of the response from service cannot be interpreted")
+		downloadErr := subErr
+		if downloadErr == nil {
+			hash := ""
+			if hashPtr, ok := artefact.manager.GetHashOk(); ok && hashPtr != nil {
+				hash = *hashPtr
+			}
+			if previousHash, downloaded := downloadedHashes[artefact.name]; downloaded && hash != "" && previousHash == hash {
+				session.report.skip(artefact.name)
+				continue
+			}
+			downloadErr = m.downloadJobArtefact(ctx, session, artefact.manager)
+			if downloadErr == nil && downloadedHashes != nil {
+				downloadedHashes[artefact.name] = hash
 			}
+		} else {
+			session.fail(artefact.name, downloadErr)
 		}
 
 		if downloadErr != nil {
-			if dlOpts.StopOnFirstError {
+			if dlOpts.StopOnFirstError && !session.provisional {
 				err = downloadErr
 				return
 			}
@@ -391,10 +797,103 @@ func (m *ArtefactManager[M, D, L, C]) DownloadAllJobArtefactsWithOptions(ctx con
 			if dlOpts.Logger != nil {
 				dlOpts.Logger.LogError(downloadErr)
 			}
-		} else if !reflection.IsEmpty(artefactName) {
+		} else if !reflection.IsEmpty(artefact.name) {
 			if dlOpts.Logger != nil {
-				dlOpts.Logger.Log(fmt.Sprintf("downloading %s", artefactName))
+				dlOpts.Logger.Log(fmt.Sprintf("downloading %s", artefact.name))
 			}
 		}
 	}
+	if len(collatedDownloadErrors) > 0 {
+		err = commonerrors.Join(collatedDownloadErrors...)
+	}
+	return
+}
+
+// IterateJobArtefacts iterates over the artefact managers of a job. Links to artefact managers are resolved as they are reached.
+// If an artefact manager cannot be resolved, the error is yielded and the iteration carries on with the following artefacts.
+// If the artefacts cannot be listed, the error is yielded and the iteration stops.
+func (m *ArtefactManager[M, D, L, C]) IterateJobArtefacts(ctx context.Context, jobName string) iter.Seq2[M, error] {
+	return func(yield func(M, error) bool) {
+		for artefact, err := range m.iterateJobArtefacts(ctx, jobName) {
+			var artefactManager M
+			if artefact != nil {
+				artefactManager = artefact.manager
+			}
+			if !yield(artefactManager, err) {
+				return
+			}
+		}
+	}
+}
+
+// ListJobArtefactManagers returns the managers of all the artefacts of a job e.g. to inspect their size or metadata before deciding what to download.
+func (m *ArtefactManager[M, D, L, C]) ListJobArtefactManagers(ctx context.Context, jobName string) (managers []M, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	managers = []M{}
+	for artefactManager, subErr := range m.IterateJobArtefacts(ctx, jobName) {
+		if subErr != nil {
+			err = subErr
+			managers = nil
+			return
+		}
+		managers = append(managers, artefactManager)
+	}
+	return
+}
+
+// jobArtefact is an artefact listed for a job.
+type jobArtefact[M IManager] struct {
+	name    string
+	manager M
+}
+
+// iterateJobArtefacts iterates over the artefacts of a job. If the artefact manager of an artefact could not be determined, the artefact is yielded along with the reason.
+// If the artefacts cannot be listed any further, no artefact is yielded with the error and the iteration stops.
+func (m *ArtefactManager[M, D, L, C]) iterateJobArtefacts(ctx context.Context, jobName string) iter.Seq2[*jobArtefact[M], error] {
+	return func(yield func(*jobArtefact[M], error) bool) {
+		paginator, err := m.ListJobArtefacts(ctx, jobName)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		stop := paginator.Stop()
+		defer stop()
+		for paginator.HasNext() {
+			item, err := paginator.GetNext()
+			if err != nil {
+				yield(nil, commonerrors.WrapError(commonerrors.ErrUnexpected, err, "failed getting information about job artefacts"))
+				return
+			}
+			artefact := &jobArtefact[M]{}
+			if artefactLink, ok := item.(D); ok {
+				artefact.name = artefactLink.GetName()
+				artefact.manager, err = m.fetchArtefactManager(ctx, jobName, artefactLink)
+			} else if artefactManager, isManager := item.(M); isManager {
+				artefact.name = artefactManager.GetName()
+				artefact.manager = artefactManager
+			} else {
+				err = commonerrors.New(commonerrors.ErrMarshalling, "the type of the response from service cannot be interpreted")
+			}
+			if !yield(artefact, err) {
+				return
+			}
+		}
+	}
+}
+
+// collateErrors joins all the errors which are not nil.
+func collateErrors(errs ...error) error {
+	var collated []error
+	for i := range errs {
+		if errs[i] != nil {
+			collated = append(collated, errs[i])
+		}
+	}
+	if len(collated) == 0 {
+		return nil
+	}
+	return commonerrors.Join(collated...)
 }
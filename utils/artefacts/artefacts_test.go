@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/go-faker/faker/v4"
@@ -26,6 +27,7 @@ import (
 	"github.com/ARM-software/golang-utils/utils/field"
 	"github.com/ARM-software/golang-utils/utils/filesystem"
 	"github.com/ARM-software/golang-utils/utils/hashing"
+	"github.com/ARM-software/golang-utils/utils/retry"
 	"github.com/ARM-software/golang-utils/utils/safecast"
 	"github.com/ARM-software/golang-utils/utils/safeio"
 )
@@ -38,6 +40,9 @@ type (
 
 type testArtefact struct {
 	name             string
+	title            string
+	extraMetadata    map[string]string
+	hashAlgorithm    string
 	path             string
 	embeddedResource bool
 	shouldFail       bool
@@ -62,7 +67,11 @@ func newTestArtefact(t *testing.T, tmpDir, artefactContent string, embeddedResou
 }
 
 func (t *testArtefact) fetchTestArtefact(ctx context.Context) (a *client.ArtefactManagerItem, err error) {
-	fileHasher, subErr := filesystem.NewFileHash(hashing.HashSha256)
+	algorithm := hashing.HashSha256
+	if t.hashAlgorithm != "" {
+		algorithm = t.hashAlgorithm
+	}
+	fileHasher, subErr := filesystem.NewFileHash(algorithm)
 	if subErr != nil {
 		err = subErr
 		return
@@ -80,18 +89,29 @@ func (t *testArtefact) fetchTestArtefact(ctx context.Context) (a *client.Artefac
 		return
 	}
 
+	if t.hashAlgorithm != "" {
+		hash = fmt.Sprintf("%v:%v", t.hashAlgorithm, strings.ToUpper(hash))
+	}
+
 	size, subErr := filesystem.GetFileSize(t.path)
 	if subErr != nil {
 		err = subErr
 		return
 	}
 
+	title := t.name
+	if t.title != "" {
+		title = t.title
+	}
 	a = &client.ArtefactManagerItem{
 		Name:  t.name,
-		Title: *client.NewNullableString(field.ToOptionalString(t.name)),
+		Title: *client.NewNullableString(field.ToOptionalString(title)),
 		Hash:  *client.NewNullableString(&hash),
 		Size:  &size,
 	}
+	if t.extraMetadata != nil {
+		a.ExtraMetadata = &t.extraMetadata
+	}
 	return
 }
 
@@ -150,6 +170,27 @@ func testGetOutputArtefact(t *testing.T, artefacts []*testArtefact) testGetArtef
 	}
 }
 
+func testGetOutputArtefactStream(t *testing.T, artefacts []*testArtefact, corrupt bool) GetArtefactContentStreamFunc {
+	t.Helper()
+	getFile := testGetOutputArtefact(t, artefacts)
+	return func(ctx context.Context, job, artefact string) (io.ReadCloser, *http.Response, error) {
+		f, resp, err := getFile(ctx, job, artefact)
+		if err != nil || f == nil {
+			return nil, resp, err
+		}
+		content, err := safeio.ReadAll(ctx, f)
+		_ = f.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		if corrupt && len(content) > 0 {
+			content[0]++
+		}
+		body := io.NopCloser(safeio.NewByteReader(ctx, content))
+		return body, &http.Response{StatusCode: http.StatusOK, Body: body}, nil
+	}
+}
+
 func testGetArtefactManagers(t *testing.T, artefacts []*testArtefact, embeddedResource bool) testGetArtefactManagersFirstPageFunc {
 	t.Helper()
 	if len(artefacts) == 0 {
@@ -351,6 +392,357 @@ func TestDetermineDestination(t *testing.T) {
 	}
 }
 
+func TestDetermineDestinationSandboxing(t *testing.T) {
+	outputDir := t.TempDir()
+	newItem := func(title string, relativePath *string) *client.ArtefactManagerItem {
+		item := &client.ArtefactManagerItem{
+			Name:  faker.Word(),
+			Title: *client.NewNullableString(field.ToOptionalString(title)),
+		}
+		if relativePath != nil {
+			item.ExtraMetadata = &map[string]string{relativePathKey: *relativePath}
+		}
+		return item
+	}
+
+	t.Run("invalid destinations", func(t *testing.T) {
+		tests := []struct {
+			title        string
+			relativePath *string
+		}{
+			{title: "../test.j"},
+			{title: "..%2Ftest.j"},
+			{title: "..\\test.j"},
+			{title: "/etc/passwd"},
+			{title: "%2Fetc%2Fpasswd"},
+			{title: "C:test.j"},
+			{title: ".."},
+			{title: ". ."},
+			{title: "test\x00.j"},
+			{title: "reports/../test.j"},
+			{title: "reports/. ./test.j"},
+			{title: "test.j", relativePath: field.ToOptionalString("../..")},
+			{title: "test.j", relativePath: field.ToOptionalString("test/../../..")},
+			{title: "test.j", relativePath: field.ToOptionalString("..\\..\\test")},
+			{title: "test.j", relativePath: field.ToOptionalString("/tmp/test")},
+			{title: "test.j", relativePath: field.ToOptionalString("\\\\server\\share")},
+			{title: "test.j", relativePath: field.ToOptionalString("C:\\Windows")},
+			{title: "test.j", relativePath: field.ToOptionalString("test/.. /test.j")},
+		}
+		for i := range tests {
+			test := tests[i]
+			t.Run(fmt.Sprintf("%d_%s", i, test.title), func(t *testing.T) {
+				_, _, err := determineArtefactDestination(outputDir, true, newItem(test.title, test.relativePath))
+				errortest.AssertError(t, err, commonerrors.ErrInvalid)
+			})
+		}
+	})
+	t.Run("sanitised destinations", func(t *testing.T) {
+		tests := []struct {
+			title            string
+			relativePath     *string
+			expectedFileName string
+			expectedDir      string
+		}{
+			{title: "CON", expectedFileName: "_CON", expectedDir: outputDir},
+			{title: "nul.txt", expectedFileName: "_nul.txt", expectedDir: outputDir},
+			{title: "com1.tar.gz", expectedFileName: "_com1.tar.gz", expectedDir: outputDir},
+			{title: "console.txt", expectedFileName: "console.txt", expectedDir: outputDir},
+			{title: "reports/out.log", expectedFileName: "out.log", expectedDir: outputDir},
+			{title: "reports%2Fout.log", expectedFileName: "out.log", expectedDir: outputDir},
+			{title: "reports\\aux.log", expectedFileName: "_aux.log", expectedDir: outputDir},
+			{title: "reports\\out.log", relativePath: field.ToOptionalString("test/reports/out.log"), expectedFileName: "out.log", expectedDir: filepath.Join(outputDir, "test", "reports")},
+			{title: "test.j", relativePath: field.ToOptionalString("test\\1\\test.j"), expectedFileName: "test.j", expectedDir: filepath.Join(outputDir, "test", "1")},
+			{title: "test.j", relativePath: field.ToOptionalString("./test/../1/"), expectedFileName: "test.j", expectedDir: filepath.Join(outputDir, "1")},
+			{title: "test.j", relativePath: field.ToOptionalString("aux/lpt1"), expectedFileName: "test.j", expectedDir: filepath.Join(outputDir, "_aux", "_lpt1")},
+		}
+		for i := range tests {
+			test := tests[i]
+			t.Run(fmt.Sprintf("%d_%s", i, test.expectedFileName), func(t *testing.T) {
+				fileName, fileDest, err := determineArtefactDestination(outputDir, true, newItem(test.title, test.relativePath))
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedFileName, fileName)
+				assert.Equal(t, test.expectedDir, fileDest)
+			})
+		}
+	})
+}
+
+func TestArtefactDownloadThroughSymlink(t *testing.T) {
+	tmpDir := t.TempDir()
+	outsideDir := t.TempDir()
+	out := t.TempDir()
+	err := os.Symlink(outsideDir, filepath.Join(out, "link"))
+	if err != nil {
+		t.Skipf("symbolic links cannot be created: %v", err)
+	}
+	m, a := newTestArtefactManagerWithEmbeddedResources(t, tmpDir, faker.Sentence())
+	item, err := a.fetchTestArtefact(context.Background())
+	require.NoError(t, err)
+
+	item.ExtraMetadata = &map[string]string{relativePathKey: "link/test"}
+	err = m.DownloadJobArtefactWithTree(context.Background(), faker.Word(), true, out, item)
+	errortest.AssertError(t, err, commonerrors.ErrInvalid)
+	empty, err := filesystem.IsEmpty(outsideDir)
+	require.NoError(t, err)
+	assert.True(t, empty)
+
+	item.ExtraMetadata = nil
+	require.NoError(t, os.Symlink(filepath.Join(outsideDir, a.name), filepath.Join(out, a.name)))
+	err = m.DownloadJobArtefactWithTree(context.Background(), faker.Word(), true, out, item)
+	errortest.AssertError(t, err, commonerrors.ErrInvalid)
+	empty, err = filesystem.IsEmpty(outsideDir)
+	require.NoError(t, err)
+	assert.True(t, empty)
+
+	require.NoError(t, filesystem.MkDir(filepath.Join(out, "inside")))
+	require.NoError(t, os.Symlink(filepath.Join(out, "inside"), filepath.Join(out, "internal")))
+	item.ExtraMetadata = &map[string]string{relativePathKey: "internal"}
+	require.NoError(t, m.DownloadJobArtefactWithTree(context.Background(), faker.Word(), true, out, item))
+	assert.FileExists(t, filepath.Join(out, "inside", a.name))
+}
+
+func TestStreamingArtefactDownload(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, true),
+	}
+	t.Run("valid content", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), testGetOutputArtefactStream(t, artefacts, false))
+		out := t.TempDir()
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithStopOnFirstError(false))
+		require.Error(t, err)
+		assert.Equal(t, 2, report.Downloaded)
+		assert.Equal(t, 1, report.Failed)
+		for i := range artefacts[:2] {
+			expectedContents, err := filesystem.ReadFile(artefacts[i].path)
+			require.NoError(t, err)
+			actualContents, err := filesystem.ReadFile(filepath.Join(out, artefacts[i].name))
+			require.NoError(t, err)
+			assert.Equal(t, expectedContents, actualContents)
+		}
+		assert.NoFileExists(t, filepath.Join(out, artefacts[2].name))
+	})
+	t.Run("corrupted content", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts[:2], true), nil, testGetArtefactManager(t, artefacts[:2]), testGetOutputArtefactStream(t, artefacts[:2], true))
+		out := t.TempDir()
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithStopOnFirstError(false))
+		errortest.AssertError(t, err, commonerrors.ErrCondition)
+		assert.Equal(t, 2, report.Failed)
+		empty, err := filesystem.IsEmpty(out)
+		require.NoError(t, err)
+		assert.True(t, empty)
+	})
+	t.Run("no content", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts[:1], true), nil, testGetArtefactManager(t, artefacts[:1]), func(context.Context, string, string) (io.ReadCloser, *http.Response, error) {
+			return nil, &http.Response{StatusCode: http.StatusOK}, nil
+		})
+		err := m.DownloadAllJobArtefacts(context.Background(), faker.Word(), t.TempDir())
+		errortest.AssertError(t, err, commonerrors.ErrEmpty)
+	})
+}
+
+// failingWriter fails to write anything e.g. as a full disk would.
+type failingWriter struct{}
+
+func (w *failingWriter) Write([]byte) (int, error) {
+	return 0, commonerrors.ErrUnexpected
+}
+
+func TestArtefactDownloadRetry(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+	}
+	retryPolicy := retry.DefaultBasicRetryPolicyConfiguration()
+	retryPolicy.RetryMax = 3
+	// flakyContent corrupts the content of the first failures attempts of each artefact and then fails with an unavailable service.
+	flakyContent := func(failures int, unavailable bool) GetArtefactContentStreamFunc {
+		validContent := testGetOutputArtefactStream(t, artefacts, false)
+		corruptedContent := testGetOutputArtefactStream(t, artefacts, true)
+		attempts := map[string]int{}
+		return func(ctx context.Context, job, artefact string) (io.ReadCloser, *http.Response, error) {
+			attempts[artefact]++
+			if attempts[artefact] > failures {
+				return validContent(ctx, job, artefact)
+			}
+			if unavailable {
+				return nil, &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return corruptedContent(ctx, job, artefact)
+		}
+	}
+
+	t.Run("corrupted transfers are retried", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), flakyContent(2, false))
+		out := t.TempDir()
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithRetryPolicy(retryPolicy))
+		require.NoError(t, err)
+		assert.Equal(t, len(artefacts), report.Downloaded)
+		for i := range report.Artefacts {
+			assert.Equal(t, 3, report.Artefacts[i].Attempts)
+		}
+		for i := range artefacts {
+			expectedContents, err := filesystem.ReadFile(artefacts[i].path)
+			require.NoError(t, err)
+			actualContents, err := filesystem.ReadFile(filepath.Join(out, artefacts[i].name))
+			require.NoError(t, err)
+			assert.Equal(t, expectedContents, actualContents)
+		}
+	})
+	t.Run("unavailable service is retried", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), flakyContent(1, true))
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithRetryPolicy(retryPolicy))
+		require.NoError(t, err)
+		assert.Equal(t, len(artefacts), report.Downloaded)
+		for i := range report.Artefacts {
+			assert.Equal(t, 2, report.Artefacts[i].Attempts)
+		}
+	})
+	t.Run("retries are limited", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), flakyContent(3, false))
+		out := t.TempDir()
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithStopOnFirstError(false), WithRetryPolicy(retryPolicy))
+		errortest.AssertError(t, err, commonerrors.ErrCondition)
+		assert.Equal(t, len(artefacts), report.Failed)
+		for i := range report.Artefacts {
+			assert.Equal(t, 3, report.Artefacts[i].Attempts)
+		}
+		empty, err := filesystem.IsEmpty(out)
+		require.NoError(t, err)
+		assert.True(t, empty)
+	})
+	t.Run("no retry by default", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), flakyContent(1, false))
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithStopOnFirstError(false))
+		errortest.AssertError(t, err, commonerrors.ErrCondition)
+		assert.Equal(t, len(artefacts), report.Failed)
+		for i := range report.Artefacts {
+			assert.Equal(t, 1, report.Artefacts[i].Attempts)
+		}
+	})
+	t.Run("interrupted transfers are retried", func(t *testing.T) {
+		validContent := testGetOutputArtefactStream(t, artefacts, false)
+		attempts := map[string]int{}
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), func(ctx context.Context, job, artefact string) (io.ReadCloser, *http.Response, error) {
+			attempts[artefact]++
+			if attempts[artefact] > 1 {
+				return validContent(ctx, job, artefact)
+			}
+			body := io.NopCloser(io.MultiReader(strings.NewReader(faker.Word()), iotest.ErrReader(commonerrors.New(commonerrors.ErrUnexpected, "connection reset by peer"))))
+			return body, &http.Response{StatusCode: http.StatusOK, Body: body}, nil
+		})
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithRetryPolicy(retryPolicy))
+		require.NoError(t, err)
+		for i := range report.Artefacts {
+			assert.Equal(t, 2, report.Artefacts[i].Attempts)
+		}
+	})
+	t.Run("storage errors are not retried", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), flakyContent(0, false))
+		sink := NewWriterSink(func(context.Context, string) (io.Writer, error) {
+			return &failingWriter{}, nil
+		})
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), "", WithSink(sink), WithStopOnFirstError(false), WithRetryPolicy(retryPolicy))
+		errortest.AssertError(t, err, commonerrors.ErrUnexpected)
+		for i := range report.Artefacts {
+			assert.Equal(t, 1, report.Artefacts[i].Attempts)
+		}
+	})
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		m := NewArtefactManagerWithStreamingContent(testGetArtefactManagers(t, artefacts, true), nil, testGetArtefactManager(t, artefacts), func(context.Context, string, string) (io.ReadCloser, *http.Response, error) {
+			return nil, &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(""))}, nil
+		})
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithRetryPolicy(retryPolicy))
+		errortest.AssertError(t, err, commonerrors.ErrForbidden)
+		require.Len(t, report.Artefacts, 1)
+		assert.Equal(t, 1, report.Artefacts[0].Attempts)
+	})
+}
+
+func TestIterateJobArtefacts(t *testing.T) {
+	tmpDir := t.TempDir()
+	var artefacts []*testArtefact
+	for i := 0; i < 3; i++ {
+		artefacts = append(artefacts, newTestArtefact(t, tmpDir, faker.Paragraph(), true, false))
+	}
+	names := collection.Map(artefacts, func(a *testArtefact) string { return a.name })
+	for _, linksOnly := range []bool{false, true} {
+		t.Run(fmt.Sprintf("links only: %v", linksOnly), func(t *testing.T) {
+			m := newTestArtefactsManager(t, artefacts, linksOnly)
+			var iterated []string
+			for artefactManager, err := range m.IterateJobArtefacts(context.Background(), faker.Word()) {
+				require.NoError(t, err)
+				require.NotNil(t, artefactManager)
+				size, ok := artefactManager.GetSizeOk()
+				require.True(t, ok)
+				assert.NotZero(t, *size)
+				iterated = append(iterated, artefactManager.GetName())
+			}
+			assert.ElementsMatch(t, names, iterated)
+
+			managers, err := m.ListJobArtefactManagers(context.Background(), faker.Word())
+			require.NoError(t, err)
+			require.Len(t, managers, len(artefacts))
+			for i := range managers {
+				expectedSize, err := filesystem.GetFileSize(filepath.Join(tmpDir, managers[i].GetName()))
+				require.NoError(t, err)
+				size, ok := managers[i].GetSizeOk()
+				require.True(t, ok)
+				assert.Equal(t, expectedSize, *size)
+			}
+		})
+	}
+	t.Run("stop iterating", func(t *testing.T) {
+		m := newTestArtefactsManager(t, artefacts, true)
+		count := 0
+		for _, err := range m.IterateJobArtefacts(context.Background(), faker.Word()) {
+			require.NoError(t, err)
+			count++
+			break
+		}
+		assert.Equal(t, 1, count)
+	})
+	t.Run("unresolved links", func(t *testing.T) {
+		getArtefactManager := testGetArtefactManager(t, artefacts)
+		m := NewArtefactManager(testGetArtefactManagers(t, artefacts, false), nil, func(ctx context.Context, job, artefact string) (*client.ArtefactManagerItem, *http.Response, error) {
+			if artefact == artefacts[1].name {
+				return nil, &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return getArtefactManager(ctx, job, artefact)
+		}, testGetOutputArtefact(t, artefacts))
+		var iterated []string
+		var errs []error
+		for artefactManager, err := range m.IterateJobArtefacts(context.Background(), faker.Word()) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			iterated = append(iterated, artefactManager.GetName())
+		}
+		require.Len(t, errs, 1)
+		errortest.AssertError(t, errs[0], commonerrors.ErrNotFound)
+		assert.ElementsMatch(t, []string{artefacts[0].name, artefacts[2].name}, iterated)
+
+		managers, err := m.ListJobArtefactManagers(context.Background(), faker.Word())
+		errortest.AssertError(t, err, commonerrors.ErrNotFound)
+		assert.Empty(t, managers)
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		m := newTestArtefactsManager(t, artefacts, false)
+		_, err := m.ListJobArtefactManagers(ctx, faker.Word())
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+		for _, err := range m.IterateJobArtefacts(ctx, faker.Word()) {
+			errortest.AssertError(t, err, commonerrors.ErrCancelled)
+		}
+	})
+}
+
 func TestArtefactDownload(t *testing.T) {
 	t.Run("Happy download artefact", func(t *testing.T) {
 		tmpDir, err := filesystem.TempDirInTempDir("test-artefact-")
@@ -413,6 +805,144 @@ func TestArtefactDownload(t *testing.T) {
 		assert.FileExists(t, filepath.Join(out, artefacts[2].name))
 
 	})
+	t.Run("Download report", func(t *testing.T) {
+		tmpDir, err := filesystem.TempDirInTempDir("test-artefact-")
+		require.NoError(t, err)
+		defer func() { _ = filesystem.Rm(tmpDir) }()
+		artefacts := []*testArtefact{
+			newTestArtefact(t, tmpDir, faker.Sentence(), true, false),
+			newTestArtefact(t, tmpDir, faker.Sentence(), true, true),
+			newTestArtefact(t, tmpDir, faker.Sentence(), true, false),
+		}
+		manager := newTestArtefactsManager(t, artefacts, false)
+
+		out := t.TempDir()
+		jobName := faker.Word()
+		report, err := manager.DownloadAllJobArtefactsWithReport(context.Background(), jobName, out, WithStopOnFirstError(false))
+		require.Error(t, err)
+		errortest.AssertError(t, err, commonerrors.ErrUnexpected)
+		require.NotNil(t, report)
+		assert.Equal(t, jobName, report.Job)
+		require.Len(t, report.Artefacts, len(artefacts))
+		assert.Equal(t, 2, report.Downloaded)
+		assert.Equal(t, 1, report.Failed)
+		assert.Zero(t, report.Skipped)
+		assert.True(t, report.HasFailures())
+		errortest.AssertError(t, report.Errors(), commonerrors.ErrUnexpected)
+		assert.NotZero(t, report.Duration)
+		totalSize := int64(0)
+		for i := range artefacts {
+			entry := report.Artefacts[i]
+			assert.Equal(t, artefacts[i].name, entry.Name)
+			assert.Equal(t, filepath.Join(out, artefacts[i].name), entry.Destination)
+			if artefacts[i].shouldFail {
+				assert.Equal(t, DownloadStatusFailed, entry.Status)
+				assert.Error(t, entry.Err)
+				continue
+			}
+			assert.Equal(t, DownloadStatusDownloaded, entry.Status)
+			assert.NoError(t, entry.Err)
+			size, err := filesystem.GetFileSize(artefacts[i].path)
+			require.NoError(t, err)
+			assert.Equal(t, size, entry.Size)
+			totalSize += size
+			hash, err := filesystem.GetGlobalFileSystem().FileHash(hashing.HashSha256, artefacts[i].path)
+			require.NoError(t, err)
+			assert.Equal(t, hash, entry.Hash)
+		}
+		assert.Equal(t, totalSize, report.TotalSize)
+		require.Len(t, report.FailedArtefacts(), 1)
+		assert.Equal(t, artefacts[1].name, report.FailedArtefacts()[0].Name)
+	})
+	t.Run("Download artefacts with progress", func(t *testing.T) {
+		tmpDir, err := filesystem.TempDirInTempDir("test-artefact-")
+		require.NoError(t, err)
+		defer func() { _ = filesystem.Rm(tmpDir) }()
+		artefacts := []*testArtefact{
+			newTestArtefact(t, tmpDir, faker.Sentence(), true, false),
+			newTestArtefact(t, tmpDir, faker.Sentence(), true, true),
+			newTestArtefact(t, tmpDir, faker.Sentence(), true, false),
+		}
+		manager := newTestArtefactsManager(t, artefacts, false)
+		reporter := &testProgressReporter{}
+
+		out := t.TempDir()
+		err = manager.DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), out, WithStopOnFirstError(false), WithProgress(reporter))
+		require.Error(t, err)
+
+		assert.Equal(t, 3, reporter.count("start"))
+		assert.Equal(t, 2, reporter.count("finish"))
+		assert.Equal(t, 1, reporter.count("error"))
+		assert.NotZero(t, reporter.count("progress"))
+		last := reporter.last()
+		expectedSize := int64(0)
+		for i := range artefacts {
+			size, err := filesystem.GetFileSize(artefacts[i].path)
+			require.NoError(t, err)
+			expectedSize += size
+		}
+		assert.Equal(t, expectedSize, last.job.ExpectedSize)
+		assert.Equal(t, 2, last.job.Completed)
+		assert.Equal(t, 1, last.job.Failed)
+	})
+	t.Run("Download artefacts with colliding names", func(t *testing.T) {
+		tmpDir, err := filesystem.TempDirInTempDir("test-artefact-collision-")
+		require.NoError(t, err)
+		defer func() { _ = filesystem.Rm(tmpDir) }()
+		title := fmt.Sprintf("%v.txt", faker.Word())
+		var artefacts []*testArtefact
+		for i := 0; i < 3; i++ {
+			a := newTestArtefact(t, tmpDir, faker.Sentence(), true, false)
+			a.title = title
+			artefacts = append(artefacts, a)
+		}
+		m := newTestArtefactsManager(t, artefacts, false)
+
+		t.Run("error", func(t *testing.T) {
+			out := t.TempDir()
+			report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithStopOnFirstError(false), WithCollisionPolicy(CollisionPolicyError))
+			errortest.AssertError(t, err, commonerrors.ErrExists)
+			assert.Equal(t, 1, report.Downloaded)
+			assert.Equal(t, 2, report.Failed)
+		})
+		t.Run("overwrite", func(t *testing.T) {
+			out := t.TempDir()
+			report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCollisionPolicy(CollisionPolicyOverwrite))
+			require.NoError(t, err)
+			assert.Equal(t, 3, report.Downloaded)
+			for i := range report.Artefacts {
+				assert.Equal(t, filepath.Join(out, title), report.Artefacts[i].Destination)
+				assert.Empty(t, report.Artefacts[i].OriginalDestination)
+			}
+		})
+		for _, policy := range []CollisionPolicy{CollisionPolicyRenameWithSuffix, CollisionPolicyRenameWithHash} {
+			t.Run(policy.String(), func(t *testing.T) {
+				out := t.TempDir()
+				report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCollisionPolicy(policy))
+				require.NoError(t, err)
+				require.Len(t, report.Artefacts, len(artefacts))
+				destinations := map[string]bool{}
+				for i := range report.Artefacts {
+					r := report.Artefacts[i]
+					assert.Equal(t, DownloadStatusDownloaded, r.Status)
+					destinations[r.Destination] = true
+					if i == 0 {
+						assert.Equal(t, filepath.Join(out, title), r.Destination)
+						assert.Empty(t, r.OriginalDestination)
+					} else {
+						assert.Equal(t, filepath.Join(out, title), r.OriginalDestination)
+					}
+					if i > 0 && policy == CollisionPolicyRenameWithHash {
+						assert.Contains(t, r.Destination, shortHash(r.Hash))
+					}
+					hash, err := filesystem.GetGlobalFileSystem().FileHash(hashing.HashSha256, r.Destination)
+					require.NoError(t, err)
+					assert.Equal(t, r.Hash, hash)
+				}
+				assert.Len(t, destinations, len(artefacts))
+			})
+		}
+	})
 	t.Run("Happy download artefact and keep tree", func(t *testing.T) {
 		tmpDir, err := filesystem.TempDirInTempDir("test-artefact-with-tree-")
 		require.NoError(t, err)
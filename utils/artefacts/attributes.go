@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/reflection"
+	"github.com/ARM-software/golang-utils/utils/safecast"
+)
+
+const (
+	// DefaultModificationTimeMetadataKey is the key of the artefact extra metadata holding the modification time of the artefact e.g. `2025-01-02T15:04:05Z` (RFC 3339) or `1735830245` (Unix time in seconds).
+	DefaultModificationTimeMetadataKey = "Modification Time"
+	// DefaultFileModeMetadataKey is the key of the artefact extra metadata holding the permissions of the artefact in octal notation e.g. `0755`.
+	DefaultFileModeMetadataKey = "File Mode"
+)
+
+// artefactAttributes are the file attributes an artefact should have once stored.
+type artefactAttributes struct {
+	// modTime is the modification time of the artefact. It is zero if unknown.
+	modTime time.Time
+	// mode holds the permissions of the artefact. It is only relevant if hasMode is set.
+	mode    os.FileMode
+	hasMode bool
+}
+
+func (a *artefactAttributes) isEmpty() bool {
+	return a == nil || (a.modTime.IsZero() && !a.hasMode)
+}
+
+// iAttributesSetter is implemented by artefact writers able to set file attributes on the artefacts they store.
+type iAttributesSetter interface {
+	// setAttributes sets the attributes to apply to the artefact when it is committed.
+	setAttributes(attributes *artefactAttributes)
+}
+
+// determineArtefactAttributes reads the file attributes of an artefact from its extra metadata. Attributes which are not present are left unset.
+func determineArtefactAttributes(metadata map[string]string, modTimeKey, modeKey string) (attributes *artefactAttributes, err error) {
+	attributes = &artefactAttributes{}
+	if modTime, found := metadata[modTimeKey]; found && !reflection.IsEmpty(modTimeKey) {
+		attributes.modTime, err = parseModificationTime(modTime)
+		if err != nil {
+			return
+		}
+	}
+	if mode, found := metadata[modeKey]; found && !reflection.IsEmpty(modeKey) {
+		attributes.mode, err = parseFileMode(mode)
+		if err != nil {
+			return
+		}
+		attributes.hasMode = true
+	}
+	return
+}
+
+func parseModificationTime(value string) (modTime time.Time, err error) {
+	value = strings.TrimSpace(value)
+	modTime, err = time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		return
+	}
+	seconds, subErr := strconv.ParseInt(value, 10, 64)
+	if subErr == nil {
+		modTime = time.Unix(seconds, 0)
+		err = nil
+		return
+	}
+	err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "invalid modification time [%v]", value)
+	return
+}
+
+// parseFileMode parses permissions in octal notation. Only permission bits are considered i.e. special bits such as setuid are ignored.
+func parseFileMode(value string) (mode os.FileMode, err error) {
+	value = strings.TrimSpace(value)
+	bits, err := strconv.ParseUint(strings.TrimPrefix(value, "0o"), 8, 32)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "invalid file mode [%v]", value)
+		return
+	}
+	mode = os.FileMode(safecast.ToUint32(bits)).Perm()
+	return
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+)
+
+func TestDetermineArtefactAttributes(t *testing.T) {
+	modTime := time.Date(2024, time.March, 12, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		metadata        map[string]string
+		expectedModTime time.Time
+		expectedMode    os.FileMode
+		expectedHasMode bool
+		expectedErr     error
+	}{
+		{metadata: nil},
+		{metadata: map[string]string{faker.Word(): faker.Word()}},
+		{metadata: map[string]string{DefaultModificationTimeMetadataKey: modTime.Format(time.RFC3339)}, expectedModTime: modTime},
+		{metadata: map[string]string{DefaultModificationTimeMetadataKey: fmt.Sprintf("%d", modTime.Unix())}, expectedModTime: modTime},
+		{metadata: map[string]string{DefaultFileModeMetadataKey: "0755"}, expectedMode: 0755, expectedHasMode: true},
+		{metadata: map[string]string{DefaultFileModeMetadataKey: "640"}, expectedMode: 0640, expectedHasMode: true},
+		{metadata: map[string]string{DefaultFileModeMetadataKey: "0o700"}, expectedMode: 0700, expectedHasMode: true},
+		{metadata: map[string]string{DefaultFileModeMetadataKey: "4755"}, expectedMode: 0755, expectedHasMode: true},
+		{metadata: map[string]string{DefaultFileModeMetadataKey: "0799"}, expectedErr: commonerrors.ErrInvalid},
+		{metadata: map[string]string{DefaultModificationTimeMetadataKey: "yesterday"}, expectedErr: commonerrors.ErrInvalid},
+	}
+	for i := range tests {
+		test := tests[i]
+		t.Run(fmt.Sprintf("%v", test.metadata), func(t *testing.T) {
+			attributes, err := determineArtefactAttributes(test.metadata, DefaultModificationTimeMetadataKey, DefaultFileModeMetadataKey)
+			if test.expectedErr != nil {
+				errortest.AssertError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.expectedModTime.Equal(attributes.modTime))
+			assert.Equal(t, test.expectedMode, attributes.mode)
+			assert.Equal(t, test.expectedHasMode, attributes.hasMode)
+		})
+	}
+}
+
+func TestDownloadPreservingAttributes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+	modTime := time.Date(2024, time.March, 12, 10, 30, 0, 0, time.UTC)
+	tmpDir := t.TempDir()
+	script := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	script.extraMetadata = map[string]string{
+		DefaultModificationTimeMetadataKey: modTime.Format(time.RFC3339),
+		DefaultFileModeMetadataKey:         "0750",
+	}
+	custom := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	custom.extraMetadata = map[string]string{
+		"mtime": fmt.Sprintf("%d", modTime.Unix()),
+		"mode":  "0700",
+	}
+	invalid := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	invalid.extraMetadata = map[string]string{DefaultFileModeMetadataKey: faker.Word()}
+	artefacts := []*testArtefact{script, custom, invalid}
+
+	t.Run("default keys", func(t *testing.T) {
+		out := t.TempDir()
+		require.NoError(t, newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), out, WithPreserveAttributes(true)))
+		info, err := os.Stat(filepath.Join(out, script.name))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+		assert.True(t, modTime.Equal(info.ModTime()))
+		info, err = os.Stat(filepath.Join(out, custom.name))
+		require.NoError(t, err)
+		assert.False(t, modTime.Equal(info.ModTime()))
+		assert.FileExists(t, filepath.Join(out, invalid.name))
+	})
+	t.Run("custom keys", func(t *testing.T) {
+		out := t.TempDir()
+		require.NoError(t, newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), out, WithPreserveAttributes(true), WithAttributeMetadataKeys("mtime", "mode")))
+		info, err := os.Stat(filepath.Join(out, custom.name))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+		assert.True(t, modTime.Equal(info.ModTime()))
+		info, err = os.Stat(filepath.Join(out, script.name))
+		require.NoError(t, err)
+		assert.False(t, modTime.Equal(info.ModTime()))
+	})
+	t.Run("disabled by default", func(t *testing.T) {
+		out := t.TempDir()
+		require.NoError(t, newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefacts(context.Background(), faker.Word(), out))
+		info, err := os.Stat(filepath.Join(out, script.name))
+		require.NoError(t, err)
+		assert.False(t, modTime.Equal(info.ModTime()))
+		assert.NotEqual(t, os.FileMode(0750), info.Mode().Perm())
+	})
+	t.Run("archive", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "artefacts.zip")
+		require.NoError(t, newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), t.TempDir(), WithPreserveAttributes(true), WithArchive(ArchiveFormatZip, archivePath)))
+		r, err := zip.OpenReader(archivePath)
+		require.NoError(t, err)
+		defer func() { _ = r.Close() }()
+		found := false
+		for _, f := range r.File {
+			if f.Name == script.name {
+				found = true
+				assert.Equal(t, os.FileMode(0750), f.Mode().Perm())
+				assert.True(t, modTime.Equal(f.Modified))
+			}
+		}
+		assert.True(t, found)
+	})
+}
@@ -0,0 +1,401 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/store"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+)
+
+const (
+	cachePartialEntryPrefix = "."
+	// cacheIndexFileName is the name of the file of the store recording when each entry was last used.
+	cacheIndexFileName = "cache-index.json"
+	// stalePartialCacheEntryAge is how long a partial entry must have been left untouched before it is considered left over by an interrupted download rather than being written by another process sharing the store.
+	stalePartialCacheEntryAge = 24 * time.Hour
+)
+
+// CacheStatistics describes how an artefact cache was used.
+type CacheStatistics struct {
+	// Hits is the number of artefacts which were found in the cache.
+	Hits int64
+	// Misses is the number of artefacts which were not found in the cache and had to be fetched.
+	Misses int64
+	// Evictions is the number of entries removed from the cache to keep it within its maximum size.
+	Evictions int64
+	// Entries is the number of artefacts currently in the cache.
+	Entries int
+	// Size is the total size in bytes of the artefacts currently in the cache.
+	Size int64
+}
+
+// ArtefactCache is a content-addressable cache of artefacts backed by a store. Artefacts are identified by their hash so that identical artefacts produced by different jobs are only fetched once.
+// When the cache exceeds its maximum size, the least recently used artefacts are evicted. A cache can be shared by concurrent downloads.
+type ArtefactCache struct {
+	mu      sync.Mutex
+	store   store.IStore
+	maxSize int64
+	loaded  bool
+	// entries maps cache keys to elements of recency. The most recently used entries are at the front of recency.
+	entries    map[string]*list.Element
+	recency    *list.List
+	statistics CacheStatistics
+	// indexOutdated states whether entries were used since the index of the store was last written.
+	indexOutdated bool
+}
+
+type cacheEntry struct {
+	key      string
+	size     int64
+	lastUsed time.Time
+}
+
+// NewArtefactCache returns a cache storing artefacts in artefactStore. The store is created if it does not exist yet and entries it already holds are reused.
+// maxSize is the maximum total size in bytes of the cached artefacts. No limit applies if maxSize is not positive.
+// The cache does not close the store.
+func NewArtefactCache(artefactStore store.IStore, maxSize int64) *ArtefactCache {
+	return &ArtefactCache{
+		store:   artefactStore,
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		recency: list.New(),
+	}
+}
+
+// Statistics returns the hits and misses recorded since the cache was created as well as its current content.
+func (c *ArtefactCache) Statistics() CacheStatistics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.statistics
+}
+
+// Clear removes all the artefacts from the cache. Statistics about hits and misses are kept.
+func (c *ArtefactCache) Clear(ctx context.Context) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err = c.store.Clear(ctx)
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not clear artefact cache")
+		return
+	}
+	c.entries = map[string]*list.Element{}
+	c.recency.Init()
+	c.statistics.Entries = 0
+	c.statistics.Size = 0
+	return
+}
+
+func cacheKey(hash *transfer.Hash) string {
+	return fmt.Sprintf("%v-%v", strings.ToLower(hash.Algorithm), strings.ToLower(hash.Digest))
+}
+
+func (c *ArtefactCache) fs() filesystem.FS {
+	return c.store.GetFilesystem()
+}
+
+func (c *ArtefactCache) entryPath(key string) string {
+	return filesystem.FilePathJoin(c.fs(), c.store.GetPath(), key)
+}
+
+// load indexes the artefacts already present in the store. How recently they were used is determined from the index of the store or from their modification time if they are not part of it.
+func (c *ArtefactCache) load(ctx context.Context) (err error) {
+	if c.loaded {
+		return
+	}
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	if !c.store.Exists() {
+		err = c.store.Create(ctx)
+		if err != nil {
+			err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not set up artefact cache")
+			return
+		}
+	}
+	names, err := c.fs().Ls(c.store.GetPath())
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not list artefacts in cache [%v]", c.store.GetPath())
+		return
+	}
+	index := c.readIndex()
+	var existing []cacheEntry
+	for i := range names {
+		if names[i] == cacheIndexFileName {
+			continue
+		}
+		entryPath := c.entryPath(names[i])
+		info, subErr := c.fs().Stat(entryPath)
+		if subErr != nil {
+			continue
+		}
+		if strings.HasPrefix(names[i], cachePartialEntryPrefix) {
+			if time.Since(info.ModTime()) > stalePartialCacheEntryAge {
+				_ = c.fs().Rm(entryPath)
+			}
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		lastUsed, indexed := index[names[i]]
+		if !indexed {
+			lastUsed = info.ModTime()
+		}
+		existing = append(existing, cacheEntry{key: names[i], size: info.Size(), lastUsed: lastUsed})
+	}
+	sort.SliceStable(existing, func(i, j int) bool { return existing[i].lastUsed.After(existing[j].lastUsed) })
+	for i := range existing {
+		c.entries[existing[i].key] = c.recency.PushBack(&existing[i])
+		c.statistics.Size += existing[i].size
+	}
+	c.statistics.Entries = c.recency.Len()
+	c.loaded = true
+	c.evict()
+	return
+}
+
+// lookup returns the path of the cached copy of the artefact with the given hash or an empty string if it is not cached. The outcome is recorded in the statistics.
+func (c *ArtefactCache) lookup(ctx context.Context, hash *transfer.Hash) (cachedPath string, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err = c.load(ctx)
+	if err != nil {
+		return
+	}
+	key := cacheKey(hash)
+	element, found := c.entries[key]
+	if !found {
+		c.statistics.Misses++
+		return
+	}
+	c.statistics.Hits++
+	// the index is only written when entries are added or removed, or when the cache is flushed, so that concurrent downloads are not serialised by hits.
+	element.Value.(*cacheEntry).lastUsed = time.Now()
+	c.recency.MoveToFront(element)
+	c.indexOutdated = true
+	cachedPath = c.entryPath(key)
+	return
+}
+
+// readIndex returns when each entry of the store was last used, as recorded by writeIndex. Entries are considered not indexed if the index cannot be read.
+func (c *ArtefactCache) readIndex() (index map[string]time.Time) {
+	index = map[string]time.Time{}
+	content, err := c.fs().ReadFile(c.entryPath(cacheIndexFileName))
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(content, &index)
+	return
+}
+
+// Flush records in the store how recently the cached artefacts were used so that other caches sharing the store evict the least recently used artefacts first.
+// This is otherwise only recorded when artefacts are added to or removed from the cache, and at the end of each download using the cache.
+func (c *ArtefactCache) Flush(ctx context.Context) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil || c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.indexOutdated {
+		return
+	}
+	err = c.writeIndex()
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not record the usage of artefact cache [%v]", c.store.GetPath())
+	}
+	return
+}
+
+// writeIndex records when each entry was last used so that the order is preserved across processes. The entries themselves are not modified as they may be hard linked to downloaded artefacts.
+// Failing to record the index only affects which entries are evicted first.
+func (c *ArtefactCache) writeIndex() (err error) {
+	index := make(map[string]time.Time, len(c.entries))
+	for key, element := range c.entries {
+		index[key] = element.Value.(*cacheEntry).lastUsed
+	}
+	content, err := json.Marshal(index)
+	if err != nil {
+		return
+	}
+	file, err := c.fs().TempFile(c.store.GetPath(), fmt.Sprintf("%v%v.partial-*", cachePartialEntryPrefix, cacheIndexFileName))
+	if err != nil {
+		return
+	}
+	_, err = file.Write(content)
+	err = collateErrors(err, file.Close())
+	if err == nil {
+		err = c.fs().Move(file.Name(), c.entryPath(cacheIndexFileName))
+	}
+	if err != nil {
+		_ = c.fs().Rm(file.Name())
+		return
+	}
+	c.indexOutdated = false
+	return
+}
+
+// verify checks that the cached copy of an artefact is still the one which was added to the cache.
+func (c *ArtefactCache) verify(ctx context.Context, cachedPath string, expectedSize int64, expectedHash *transfer.Hash) (err error) {
+	hasher, err := transfer.NewHashingAlgorithm(expectedHash.Algorithm)
+	if err != nil {
+		return
+	}
+	actualSize, err := c.fs().GetFileSize(cachedPath)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not read cached artefact [%v]", cachedPath)
+		return
+	}
+	if actualSize != expectedSize {
+		err = commonerrors.Newf(commonerrors.ErrCondition, "cached artefact [%v] size '%v' does not match expected '%v'", cachedPath, actualSize, expectedSize)
+		return
+	}
+	f, err := c.fs().GenericOpen(cachedPath)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not open cached artefact [%v]", cachedPath)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	actualHash, err := hasher.CalculateWithContext(ctx, f)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not calculate hash of cached artefact [%v]", cachedPath)
+		return
+	}
+	if !expectedHash.Matches(actualHash) {
+		err = commonerrors.Newf(commonerrors.ErrCondition, "cached artefact [%v] %v hash '%v' does not match expected '%v'", cachedPath, expectedHash.Algorithm, actualHash, expectedHash.Digest)
+	}
+	return
+}
+
+// discard removes the artefact with the given hash from the cache e.g. because its cached copy is corrupted.
+func (c *ArtefactCache) discard(hash *transfer.Hash) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, found := c.entries[cacheKey(hash)]; found {
+		c.remove(element)
+		_ = c.writeIndex()
+	}
+}
+
+// add records an artefact which was moved into the store.
+func (c *ArtefactCache) add(key string, size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, found := c.entries[key]; found {
+		c.statistics.Size -= element.Value.(*cacheEntry).size
+		c.recency.Remove(element)
+	}
+	c.entries[key] = c.recency.PushFront(&cacheEntry{key: key, size: size, lastUsed: time.Now()})
+	c.statistics.Size += size
+	c.statistics.Entries = c.recency.Len()
+	c.evict()
+	_ = c.writeIndex()
+}
+
+// evict removes the least recently used artefacts until the cache fits within its maximum size.
+func (c *ArtefactCache) evict() {
+	for c.maxSize > 0 && c.statistics.Size > c.maxSize && c.recency.Len() > 0 {
+		c.remove(c.recency.Back())
+		c.statistics.Evictions++
+	}
+}
+
+func (c *ArtefactCache) remove(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	_ = c.fs().Rm(c.entryPath(entry.key))
+	c.recency.Remove(element)
+	delete(c.entries, entry.key)
+	c.statistics.Size -= entry.size
+	c.statistics.Entries = c.recency.Len()
+}
+
+// newWriter returns a writer adding the artefact with the given hash to the cache once committed, or nil if the artefact cannot be cached e.g. because it is larger than the cache.
+func (c *ArtefactCache) newWriter(ctx context.Context, hash *transfer.Hash, expectedSize int64) (w *cacheWriter, err error) {
+	if c == nil || (c.maxSize > 0 && expectedSize > c.maxSize) {
+		return
+	}
+	c.mu.Lock()
+	err = c.load(ctx)
+	c.mu.Unlock()
+	if err != nil {
+		return
+	}
+	key := cacheKey(hash)
+	file, err := c.fs().TempFile(c.store.GetPath(), fmt.Sprintf("%v%v.partial-*", cachePartialEntryPrefix, key))
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not create an entry in artefact cache [%v]", c.store.GetPath())
+		return
+	}
+	w = &cacheWriter{cache: c, key: key, file: file}
+	return
+}
+
+// cacheWriter writes an artefact to a temporary file of the cache which only becomes an entry once committed i.e. once the artefact has been verified.
+type cacheWriter struct {
+	cache *ArtefactCache
+	key   string
+	file  filesystem.File
+	size  int64
+	done  bool
+}
+
+func (w *cacheWriter) Write(p []byte) (n int, err error) {
+	n, err = w.file.Write(p)
+	w.size += int64(n)
+	return
+}
+
+func (w *cacheWriter) commit() (err error) {
+	if w.done {
+		return
+	}
+	w.done = true
+	err = w.file.Close()
+	if err == nil {
+		err = w.cache.fs().Move(w.file.Name(), w.cache.entryPath(w.key))
+	}
+	if err != nil {
+		_ = w.cache.fs().Rm(w.file.Name())
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not add artefact to cache [%v]", w.cache.store.GetPath())
+		return
+	}
+	w.cache.add(w.key, w.size)
+	return
+}
+
+func (w *cacheWriter) abort() {
+	if w.done {
+		return
+	}
+	w.done = true
+	_ = w.file.Close()
+	_ = w.cache.fs().Rm(w.file.Name())
+}
+
+// iArtefactLinker is implemented by sinks able to store an artefact as a hard link to an existing file rather than a copy.
+type iArtefactLinker interface {
+	// link stores the file at sourcePath of fs as the artefact at relativePath.
+	link(ctx context.Context, relativePath string, fs filesystem.FS, sourcePath string) error
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/store"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+func TestDownloadWithCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), false, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+	}
+	cacheDir := t.TempDir()
+	cache := NewArtefactCache(store.NewLocalStore(cacheDir), 0)
+
+	assertDownloaded := func(t *testing.T, out string) {
+		t.Helper()
+		for i := range artefacts {
+			expected, err := filesystem.ReadFile(artefacts[i].path)
+			require.NoError(t, err)
+			actual, err := filesystem.ReadFile(filepath.Join(out, artefacts[i].name))
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		}
+	}
+	isLinkedToCache := func(t *testing.T, artefactPath string) bool {
+		t.Helper()
+		info, err := os.Stat(artefactPath)
+		require.NoError(t, err)
+		entries, err := os.ReadDir(cacheDir)
+		require.NoError(t, err)
+		for i := range entries {
+			entryInfo, err := os.Stat(filepath.Join(cacheDir, entries[i].Name()))
+			require.NoError(t, err)
+			if os.SameFile(info, entryInfo) {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("miss", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCache(cache))
+		require.NoError(t, err)
+		assertDownloaded(t, out)
+		for i := range report.Artefacts {
+			assert.False(t, report.Artefacts[i].FromCache)
+		}
+		statistics := cache.Statistics()
+		assert.Equal(t, int64(0), statistics.Hits)
+		assert.Equal(t, int64(len(artefacts)), statistics.Misses)
+		assert.Equal(t, len(artefacts), statistics.Entries)
+		assert.NotZero(t, statistics.Size)
+	})
+	t.Run("hit", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCache(cache))
+		require.NoError(t, err)
+		assertDownloaded(t, out)
+		for i := range report.Artefacts {
+			assert.True(t, report.Artefacts[i].FromCache)
+			// artefacts are copied by default.
+			assert.False(t, isLinkedToCache(t, report.Artefacts[i].Destination))
+		}
+		statistics := cache.Statistics()
+		assert.Equal(t, int64(len(artefacts)), statistics.Hits)
+		assert.Equal(t, int64(len(artefacts)), statistics.Misses)
+		// hits are recorded in the store at the end of the download.
+		assert.False(t, cache.indexOutdated)
+	})
+	t.Run("hit with hard links", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCache(cache), WithCacheHardLinks(true))
+		require.NoError(t, err)
+		assertDownloaded(t, out)
+		modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		for i := range report.Artefacts {
+			assert.True(t, report.Artefacts[i].FromCache)
+			assert.True(t, isLinkedToCache(t, report.Artefacts[i].Destination))
+			require.NoError(t, os.Chtimes(report.Artefacts[i].Destination, modTime, modTime))
+		}
+		// using the cache again does not modify artefacts previously linked to its entries.
+		_, err = newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithCache(cache), WithCacheHardLinks(true))
+		require.NoError(t, err)
+		for i := range report.Artefacts {
+			info, err := os.Stat(report.Artefacts[i].Destination)
+			require.NoError(t, err)
+			assert.True(t, modTime.Equal(info.ModTime()))
+		}
+	})
+	t.Run("hit with copies", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "artefacts.zip")
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithCache(cache), WithArchive(ArchiveFormatZip, archivePath))
+		require.NoError(t, err)
+		for i := range report.Artefacts {
+			assert.True(t, report.Artefacts[i].FromCache)
+		}
+		r, err := zip.OpenReader(archivePath)
+		require.NoError(t, err)
+		defer func() { _ = r.Close() }()
+		assert.Len(t, r.File, len(artefacts)+2)
+	})
+	t.Run("storage errors keep entries", func(t *testing.T) {
+		entries := cache.Statistics().Entries
+		sink := NewWriterSink(func(context.Context, string) (io.Writer, error) {
+			return &failingWriter{}, nil
+		})
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), "", WithCache(cache), WithSink(sink), WithStopOnFirstError(false))
+		errortest.AssertError(t, err, commonerrors.ErrUnexpected)
+		assert.Equal(t, len(artefacts), report.Failed)
+		for i := range report.Artefacts {
+			assert.Equal(t, 1, report.Artefacts[i].Attempts)
+		}
+		// entries are only discarded when their content is at fault.
+		assert.Equal(t, entries, cache.Statistics().Entries)
+		out := t.TempDir()
+		report, err = newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCache(cache))
+		require.NoError(t, err)
+		assertDownloaded(t, out)
+		for i := range report.Artefacts {
+			assert.True(t, report.Artefacts[i].FromCache)
+		}
+	})
+	t.Run("corrupted entries", func(t *testing.T) {
+		entries, err := os.ReadDir(cacheDir)
+		require.NoError(t, err)
+		for i := range entries {
+			entryPath := filepath.Join(cacheDir, entries[i].Name())
+			require.NoError(t, filesystem.Rm(entryPath))
+			require.NoError(t, filesystem.WriteFile(entryPath, []byte(faker.Sentence()), 0600))
+		}
+		out := t.TempDir()
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCache(cache))
+		require.NoError(t, err)
+		assertDownloaded(t, out)
+		for i := range report.Artefacts {
+			assert.False(t, report.Artefacts[i].FromCache)
+		}
+		// corrupted entries are replaced by the artefacts fetched.
+		out = t.TempDir()
+		report, err = newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithCache(cache))
+		require.NoError(t, err)
+		assertDownloaded(t, out)
+		for i := range report.Artefacts {
+			assert.True(t, report.Artefacts[i].FromCache)
+		}
+	})
+	t.Run("clear", func(t *testing.T) {
+		require.NoError(t, cache.Clear(context.Background()))
+		statistics := cache.Statistics()
+		assert.Zero(t, statistics.Entries)
+		assert.Zero(t, statistics.Size)
+		empty, err := filesystem.IsEmpty(cacheDir)
+		require.NoError(t, err)
+		assert.True(t, empty)
+	})
+}
+
+func TestArtefactCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	put := func(t *testing.T, cache *ArtefactCache, digest string) {
+		t.Helper()
+		w, err := cache.newWriter(ctx, &transfer.Hash{Algorithm: DefaultHashAlgorithm, Digest: digest}, 100)
+		require.NoError(t, err)
+		require.NotNil(t, w)
+		_, err = w.Write([]byte(strings.Repeat("a", 100)))
+		require.NoError(t, err)
+		require.NoError(t, w.commit())
+	}
+	isCached := func(t *testing.T, cache *ArtefactCache, digest string) bool {
+		t.Helper()
+		cachedPath, err := cache.lookup(ctx, &transfer.Hash{Algorithm: DefaultHashAlgorithm, Digest: digest})
+		require.NoError(t, err)
+		return cachedPath != ""
+	}
+
+	cache := NewArtefactCache(store.NewLocalStore(cacheDir), 250)
+	put(t, cache, "first")
+	put(t, cache, "second")
+	assert.True(t, isCached(t, cache, "first"))
+	put(t, cache, "third")
+	statistics := cache.Statistics()
+	assert.Equal(t, 2, statistics.Entries)
+	assert.Equal(t, int64(200), statistics.Size)
+	assert.Equal(t, int64(1), statistics.Evictions)
+	assert.False(t, isCached(t, cache, "second"))
+	assert.True(t, isCached(t, cache, "first"))
+	assert.True(t, isCached(t, cache, "third"))
+
+	w, err := cache.newWriter(ctx, &transfer.Hash{Algorithm: DefaultHashAlgorithm, Digest: "large"}, 300)
+	require.NoError(t, err)
+	assert.Nil(t, w)
+
+	// entries are reused by other caches using the same store.
+	reloaded := NewArtefactCache(store.NewLocalStore(cacheDir), 250)
+	assert.True(t, isCached(t, reloaded, "third"))
+	statistics = reloaded.Statistics()
+	assert.Equal(t, 2, statistics.Entries)
+	assert.Equal(t, int64(200), statistics.Size)
+	// how recently entries were used is only shared once the cache is flushed.
+	assert.True(t, isCached(t, reloaded, "first"))
+	require.NoError(t, reloaded.Flush(ctx))
+	reloaded = NewArtefactCache(store.NewLocalStore(cacheDir), 250)
+	put(t, reloaded, "fourth")
+	assert.True(t, isCached(t, reloaded, "first"))
+	assert.False(t, isCached(t, reloaded, "third"))
+
+	var undefined *ArtefactCache
+	assert.NoError(t, undefined.Flush(ctx))
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	errortest.AssertError(t, reloaded.Flush(cancelledCtx), commonerrors.ErrCancelled)
+}
+
+func TestArtefactCachePartialEntries(t *testing.T) {
+	cacheDir := t.TempDir()
+	stale := filepath.Join(cacheDir, cachePartialEntryPrefix+faker.Word()+".partial-stale")
+	inProgress := filepath.Join(cacheDir, cachePartialEntryPrefix+faker.Word()+".partial-in-progress")
+	require.NoError(t, filesystem.WriteFile(stale, []byte(faker.Sentence()), 0600))
+	require.NoError(t, filesystem.WriteFile(inProgress, []byte(faker.Sentence()), 0600))
+	modTime := time.Now().Add(-2 * stalePartialCacheEntryAge)
+	require.NoError(t, os.Chtimes(stale, modTime, modTime))
+
+	cache := NewArtefactCache(store.NewLocalStore(cacheDir), 0)
+	cachedPath, err := cache.lookup(context.Background(), &transfer.Hash{Algorithm: DefaultHashAlgorithm, Digest: faker.UUIDDigit()})
+	require.NoError(t, err)
+	assert.Empty(t, cachedPath)
+	assert.NoFileExists(t, stale)
+	// partial entries may be written by another process sharing the store.
+	assert.FileExists(t, inProgress)
+	assert.Zero(t, cache.Statistics().Entries)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+)
+
+const shortHashLength = 8
+
+// CollisionPolicy describes what happens when several artefacts of a same download are meant to be stored at the same location.
+type CollisionPolicy int
+
+const (
+	// CollisionPolicyOverwrite states that the last artefact downloaded to a location overwrites any previous one.
+	CollisionPolicyOverwrite CollisionPolicy = iota
+	// CollisionPolicyError states that an artefact cannot be downloaded if its location is already used by another artefact.
+	CollisionPolicyError
+	// CollisionPolicyRenameWithSuffix states that a numeric suffix is appended to the file name of an artefact whose location is already used e.g. `file_1.txt`.
+	CollisionPolicyRenameWithSuffix
+	// CollisionPolicyRenameWithHash states that the beginning of the artefact's hash is appended to the file name of an artefact whose location is already used e.g. `file_1a2b3c4d.txt`.
+	CollisionPolicyRenameWithHash
+)
+
+func (p CollisionPolicy) String() string {
+	switch p {
+	case CollisionPolicyError:
+		return "error"
+	case CollisionPolicyRenameWithSuffix:
+		return "rename with suffix"
+	case CollisionPolicyRenameWithHash:
+		return "rename with hash"
+	default:
+		return "overwrite"
+	}
+}
+
+// destinationRegistry keeps track of the locations claimed by artefacts during a download so that collisions can be detected.
+type destinationRegistry struct {
+	mu      sync.Mutex
+	policy  CollisionPolicy
+	claimed map[string]string
+	// reserved lists the locations of files written alongside artefacts e.g. the manifest, along with a description of these files.
+	reserved map[string]string
+}
+
+func newDestinationRegistry(policy CollisionPolicy) *destinationRegistry {
+	return &destinationRegistry{
+		policy:   policy,
+		claimed:  map[string]string{},
+		reserved: map[string]string{},
+	}
+}
+
+// reserve prevents artefacts from being stored at a location so that a file written alongside them e.g. the manifest, does not overwrite any of them.
+// Artefacts meant to be stored at a reserved location are renamed if the collision policy allows it or cannot be downloaded otherwise, whatever the policy.
+func (r *destinationRegistry) reserve(destination, description string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reserved[path.Clean(destination)] = description
+}
+
+// claim reserves a location for an artefact and returns the path where the artefact should actually be stored according to the collision policy.
+func (r *destinationRegistry) claim(destination, artefactName, artefactHash string) (chosen string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	destination = path.Clean(destination)
+	if description, reserved := r.reserved[destination]; reserved && r.policy != CollisionPolicyRenameWithSuffix && r.policy != CollisionPolicyRenameWithHash {
+		err = commonerrors.Newf(commonerrors.ErrExists, "artefact [%v] cannot be stored at [%v] as this location is used by the %v", artefactName, destination, description)
+		return
+	}
+	owner, taken := r.claimed[destination]
+	_, reserved := r.reserved[destination]
+	if !reserved && (!taken || owner == artefactName) {
+		r.claimed[destination] = artefactName
+		chosen = destination
+		return
+	}
+	switch r.policy {
+	case CollisionPolicyError:
+		err = commonerrors.Newf(commonerrors.ErrExists, "artefact [%v] cannot be stored at [%v] as this location is already used by artefact [%v]", artefactName, destination, owner)
+		return
+	case CollisionPolicyRenameWithHash:
+		if hash := shortHash(artefactHash); hash != "" {
+			chosen = r.firstAvailable(withFileNameSuffix(destination, hash), artefactName)
+			return
+		}
+		chosen = r.firstAvailable(destination, artefactName)
+	case CollisionPolicyRenameWithSuffix:
+		chosen = r.firstAvailable(destination, artefactName)
+	default:
+		r.claimed[destination] = artefactName
+		chosen = destination
+	}
+	return
+}
+
+// claimExactly reserves a location for a file which cannot be renamed e.g. an entry extracted from an archive. Any collision is an error, whatever the collision policy.
+func (r *destinationRegistry) claimExactly(destination, owner string) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	destination = path.Clean(destination)
+	if description, reserved := r.reserved[destination]; reserved {
+		err = commonerrors.Newf(commonerrors.ErrExists, "[%v] cannot be stored at [%v] as this location is used by the %v", owner, destination, description)
+		return
+	}
+	if existing, taken := r.claimed[destination]; taken && existing != owner {
+		err = commonerrors.Newf(commonerrors.ErrExists, "[%v] cannot be stored at [%v] as this location is already used by artefact [%v]", owner, destination, existing)
+		return
+	}
+	r.claimed[destination] = owner
+	return
+}
+
+// firstAvailable claims the first location derived from destination which is not already used.
+func (r *destinationRegistry) firstAvailable(destination, artefactName string) string {
+	candidate := destination
+	for i := 1; ; i++ {
+		_, reserved := r.reserved[candidate]
+		if owner, taken := r.claimed[candidate]; !reserved && (!taken || owner == artefactName) {
+			r.claimed[candidate] = artefactName
+			return candidate
+		}
+		candidate = withFileNameSuffix(destination, fmt.Sprintf("%d", i))
+	}
+}
+
+func withFileNameSuffix(p, suffix string) string {
+	ext := path.Ext(p)
+	return fmt.Sprintf("%v_%v%v", strings.TrimSuffix(p, ext), suffix, ext)
+}
+
+// shortHash returns the first few characters of a hash, ignoring any algorithm prefix e.g. `sha256:`.
+func shortHash(hash string) string {
+	if i := strings.LastIndex(hash, ":"); i >= 0 {
+		hash = hash[i+1:]
+	}
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if len(hash) > shortHashLength {
+		hash = hash[:shortHashLength]
+	}
+	return hash
+}
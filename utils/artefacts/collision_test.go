@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"path"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+)
+
+func TestDestinationRegistry(t *testing.T) {
+	destination := path.Join(faker.Word(), "test.tar.gz")
+	hash := "sha256:1A2B3C4D5E6F7A8B9C0D"
+	tests := []struct {
+		policy   CollisionPolicy
+		expected []string
+	}{
+		{
+			policy:   CollisionPolicyOverwrite,
+			expected: []string{destination, destination, destination},
+		},
+		{
+			policy:   CollisionPolicyRenameWithSuffix,
+			expected: []string{destination, withFileNameSuffix(destination, "1"), withFileNameSuffix(destination, "2")},
+		},
+		{
+			policy:   CollisionPolicyRenameWithHash,
+			expected: []string{destination, path.Join(path.Dir(destination), "test.tar_1a2b3c4d.gz"), path.Join(path.Dir(destination), "test.tar_1a2b3c4d_1.gz")},
+		},
+	}
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.policy.String(), func(t *testing.T) {
+			registry := newDestinationRegistry(test.policy)
+			for j := range test.expected {
+				chosen, err := registry.claim(destination, faker.UUIDHyphenated(), hash)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected[j], chosen)
+			}
+		})
+	}
+	t.Run(CollisionPolicyError.String(), func(t *testing.T) {
+		registry := newDestinationRegistry(CollisionPolicyError)
+		name := faker.UUIDHyphenated()
+		chosen, err := registry.claim(destination, name, hash)
+		require.NoError(t, err)
+		assert.Equal(t, destination, chosen)
+		chosen, err = registry.claim(destination, name, hash)
+		require.NoError(t, err)
+		assert.Equal(t, destination, chosen)
+		_, err = registry.claim(destination, faker.UUIDHyphenated(), hash)
+		errortest.AssertError(t, err, commonerrors.ErrExists)
+	})
+	t.Run("exact", func(t *testing.T) {
+		registry := newDestinationRegistry(CollisionPolicyRenameWithSuffix)
+		registry.reserve(DefaultManifestFileName, "artefact manifest")
+		owner := faker.UUIDHyphenated()
+		require.NoError(t, registry.claimExactly(destination, owner))
+		require.NoError(t, registry.claimExactly(destination, owner))
+		errortest.AssertError(t, registry.claimExactly(destination, faker.UUIDHyphenated()), commonerrors.ErrExists)
+		errortest.AssertError(t, registry.claimExactly(DefaultManifestFileName, owner), commonerrors.ErrExists)
+		// other artefacts are renamed according to the policy.
+		chosen, err := registry.claim(destination, faker.UUIDHyphenated(), hash)
+		require.NoError(t, err)
+		assert.Equal(t, withFileNameSuffix(destination, "1"), chosen)
+	})
+	t.Run("reserved", func(t *testing.T) {
+		for _, policy := range []CollisionPolicy{CollisionPolicyOverwrite, CollisionPolicyError} {
+			registry := newDestinationRegistry(policy)
+			registry.reserve(DefaultManifestFileName, "artefact manifest")
+			_, err := registry.claim(DefaultManifestFileName, faker.UUIDHyphenated(), hash)
+			errortest.AssertError(t, err, commonerrors.ErrExists)
+		}
+		registry := newDestinationRegistry(CollisionPolicyRenameWithSuffix)
+		registry.reserve(DefaultChecksumFileName, "artefact checksum file")
+		chosen, err := registry.claim(DefaultChecksumFileName, faker.UUIDHyphenated(), hash)
+		require.NoError(t, err)
+		assert.Equal(t, withFileNameSuffix(DefaultChecksumFileName, "1"), chosen)
+	})
+}
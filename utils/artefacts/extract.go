@@ -0,0 +1,235 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+	"github.com/ARM-software/golang-utils/utils/safecast"
+	"github.com/ARM-software/golang-utils/utils/safeio"
+)
+
+// archiveExtensions maps the file extensions of archives which can be extracted to their format. Longer extensions come first so that `.tar.gz` is not mistaken for `.gz`.
+var archiveExtensions = []struct {
+	extension string
+	format    ArchiveFormat
+}{
+	{extension: ".tar.gz", format: ArchiveFormatTarGz},
+	{extension: ".tgz", format: ArchiveFormatTarGz},
+	{extension: ".zip", format: ArchiveFormatZip},
+}
+
+// determineExtractionDirectory returns the format of the archive stored at relativePath and the slash-separated directory it should be extracted to i.e. a directory named after the archive.
+// The format is ArchiveFormatNone if relativePath does not correspond to a supported archive.
+func determineExtractionDirectory(relativePath string) (format ArchiveFormat, directory string) {
+	base := path.Base(relativePath)
+	for i := range archiveExtensions {
+		extension := archiveExtensions[i].extension
+		if len(base) > len(extension) && strings.EqualFold(base[len(base)-len(extension):], extension) {
+			format = archiveExtensions[i].format
+			directory = path.Join(path.Dir(relativePath), base[:len(base)-len(extension)])
+			return
+		}
+	}
+	return
+}
+
+// iArtefactRemover is implemented by sinks able to remove files they stored.
+type iArtefactRemover interface {
+	// remove deletes the file stored at relativePath as well as any of its parent directories left empty.
+	remove(ctx context.Context, relativePath string) error
+}
+
+// archiveExtractor writes the entries of an archive into a sink while enforcing limits on what is extracted.
+type archiveExtractor struct {
+	sink         IArtefactSink
+	destinations *destinationRegistry
+	archiveName  string
+	directory    string
+	limits       filesystem.ILimits
+	fileCount    int64
+	totalSize    uint64
+	// extracted lists the entries already stored in the sink so that they can be removed if the archive cannot be fully extracted.
+	extracted []string
+}
+
+func newArchiveExtractor(sink IArtefactSink, destinations *destinationRegistry, archiveName, directory string, limits filesystem.ILimits) *archiveExtractor {
+	if limits == nil {
+		limits = filesystem.NoLimits()
+	}
+	if destinations == nil {
+		destinations = newDestinationRegistry(CollisionPolicyOverwrite)
+	}
+	return &archiveExtractor{sink: sink, destinations: destinations, archiveName: archiveName, directory: directory, limits: limits}
+}
+
+// extract extracts the archive staged in file into the extractor's directory. Either the whole archive is extracted or, if the sink allows it, none of its entries are left behind.
+func (e *archiveExtractor) extract(ctx context.Context, format ArchiveFormat, file filesystem.File) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			e.removeExtracted()
+		}
+	}()
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not read archive to extract into [%v]", e.directory)
+		return
+	}
+	switch format {
+	case ArchiveFormatZip:
+		err = e.extractZip(ctx, file)
+	case ArchiveFormatTarGz:
+		err = e.extractTarGz(ctx, file)
+	default:
+		err = commonerrors.Newf(commonerrors.ErrUnsupported, "unsupported archive format [%v]", format)
+	}
+	return
+}
+
+func (e *archiveExtractor) extractZip(ctx context.Context, file filesystem.File) (err error) {
+	info, err := file.Stat()
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not read archive to extract into [%v]", e.directory)
+		return
+	}
+	reader, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "invalid zip archive to extract into [%v]", e.directory)
+		return
+	}
+	for _, entry := range reader.File {
+		err = parallelisation.DetermineContextError(ctx)
+		if err != nil {
+			return
+		}
+		// only regular files are extracted: directories are created as needed and links could point outside the extraction directory.
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		content, subErr := entry.Open()
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, subErr, "could not read entry [%v] of zip archive", entry.Name)
+			return
+		}
+		err = e.extractEntry(ctx, entry.Name, content)
+		_ = content.Close()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (e *archiveExtractor) extractTarGz(ctx context.Context, file filesystem.File) (err error) {
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "invalid tarball to extract into [%v]", e.directory)
+		return
+	}
+	defer func() { _ = gzipReader.Close() }()
+	reader := tar.NewReader(gzipReader)
+	for {
+		err = parallelisation.DetermineContextError(ctx)
+		if err != nil {
+			return
+		}
+		header, subErr := reader.Next()
+		if commonerrors.Any(subErr, io.EOF) {
+			return
+		}
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, subErr, "invalid tarball to extract into [%v]", e.directory)
+			return
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		err = e.extractEntry(ctx, header.Name, reader)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// extractEntry writes an archive entry into the sink. Entries which would end up outside the extraction directory are rejected.
+func (e *archiveExtractor) extractEntry(ctx context.Context, entryName string, content io.Reader) (err error) {
+	entryPath, err := sanitiseRelativePath(entryName)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "archive entry [%v] cannot be extracted", entryName)
+		return
+	}
+	if entryPath == "" {
+		return
+	}
+	e.fileCount++
+	if e.limits.Apply() && e.fileCount > e.limits.GetMaxFileCount() {
+		err = commonerrors.Newf(commonerrors.ErrTooLarge, "archive contains more than %v files", e.limits.GetMaxFileCount())
+		return
+	}
+	relativePath := path.Join(e.directory, filepath.ToSlash(entryPath))
+	// entries cannot be renamed as other entries may refer to them, so any collision is an error.
+	err = e.destinations.claimExactly(relativePath, e.archiveName)
+	if err != nil {
+		err = commonerrors.DescribeCircumstanceAndKeepTypef(err, "archive entry [%v] cannot be extracted", entryName)
+		return
+	}
+	w, err := e.sink.Create(ctx, relativePath)
+	if err != nil {
+		return
+	}
+	if e.limits.Apply() {
+		// one more byte than allowed is read so that entries exceeding the limit can be detected.
+		content = io.LimitReader(content, e.limits.GetMaxFileSize()+1)
+	}
+	written, err := safeio.CopyDataWithContext(ctx, content, w)
+	if err == nil && e.limits.Apply() {
+		e.totalSize += safecast.ToUint64(written)
+		if written > e.limits.GetMaxFileSize() {
+			err = commonerrors.Newf(commonerrors.ErrTooLarge, "archive entry [%v] is larger than %v bytes", entryName, e.limits.GetMaxFileSize())
+		} else if e.totalSize > e.limits.GetMaxTotalSize() {
+			err = commonerrors.Newf(commonerrors.ErrTooLarge, "archive content is larger than %v bytes", e.limits.GetMaxTotalSize())
+		}
+	}
+	if err != nil {
+		_ = w.Abort()
+		if !commonerrors.Any(err, commonerrors.ErrTooLarge) {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not extract archive entry [%v]", entryName)
+		}
+		return
+	}
+	err = w.Commit(ctx)
+	if err == nil {
+		e.extracted = append(e.extracted, relativePath)
+	}
+	return
+}
+
+// removeExtracted removes the entries already extracted, if the sink allows it. Errors are ignored as the extraction has already failed.
+func (e *archiveExtractor) removeExtracted() {
+	remover, ok := e.sink.(iArtefactRemover)
+	if !ok {
+		return
+	}
+	for i := range e.extracted {
+		// entries are removed even if the download was cancelled.
+		_ = remover.remove(context.Background(), e.extracted[i])
+	}
+	e.extracted = nil
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+func newTestZip(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for name, content := range entries {
+		entry, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return b.Bytes()
+}
+
+func newTestTarGz(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	gzipWriter := gzip.NewWriter(&b)
+	w := tar.NewWriter(gzipWriter)
+	require.NoError(t, w.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: "link", Linkname: "/etc/passwd"}))
+	for name, content := range entries {
+		require.NoError(t, w.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(content)), Mode: 0600}))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, gzipWriter.Close())
+	return b.Bytes()
+}
+
+func newTestArchiveArtefact(t *testing.T, tmpDir, name string, content []byte) *testArtefact {
+	t.Helper()
+	p := filepath.Join(tmpDir, name)
+	require.NoError(t, filesystem.WriteFile(p, content, 0777))
+	return &testArtefact{name: name, path: p, embeddedResource: true}
+}
+
+func TestDetermineExtractionDirectory(t *testing.T) {
+	tests := []struct {
+		relativePath      string
+		expectedFormat    ArchiveFormat
+		expectedDirectory string
+	}{
+		{relativePath: "output.zip", expectedFormat: ArchiveFormatZip, expectedDirectory: "output"},
+		{relativePath: "a/b/output.ZIP", expectedFormat: ArchiveFormatZip, expectedDirectory: "a/b/output"},
+		{relativePath: "traces.tar.gz", expectedFormat: ArchiveFormatTarGz, expectedDirectory: "traces"},
+		{relativePath: "traces.tgz", expectedFormat: ArchiveFormatTarGz, expectedDirectory: "traces"},
+		{relativePath: "traces.gz", expectedFormat: ArchiveFormatNone},
+		{relativePath: ".zip", expectedFormat: ArchiveFormatNone},
+		{relativePath: "output.txt", expectedFormat: ArchiveFormatNone},
+	}
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.relativePath, func(t *testing.T) {
+			format, directory := determineExtractionDirectory(test.relativePath)
+			assert.Equal(t, test.expectedFormat, format)
+			assert.Equal(t, test.expectedDirectory, directory)
+		})
+	}
+}
+
+func TestDownloadWithArchiveExtraction(t *testing.T) {
+	entries := map[string]string{
+		"logs/run.log": faker.Paragraph(),
+		"trace.bin":    faker.Paragraph(),
+	}
+	tmpDir := t.TempDir()
+	zipArtefact := newTestArchiveArtefact(t, tmpDir, "output.zip", newTestZip(t, entries))
+	tarArtefact := newTestArchiveArtefact(t, tmpDir, "traces.tar.gz", newTestTarGz(t, entries))
+	textArtefact := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	artefacts := []*testArtefact{zipArtefact, tarArtefact, textArtefact}
+
+	assertExtracted := func(t *testing.T, directory string) {
+		t.Helper()
+		for name, content := range entries {
+			actual, err := filesystem.ReadFile(filepath.Join(directory, filepath.FromSlash(name)))
+			require.NoError(t, err)
+			assert.Equal(t, content, string(actual))
+		}
+		assert.NoFileExists(t, filepath.Join(directory, "link"))
+	}
+
+	t.Run("keep archives", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithExtractArchives(true, false), WithManifest(true))
+		require.NoError(t, err)
+		assert.Equal(t, len(artefacts), report.Downloaded)
+		assertExtracted(t, filepath.Join(out, "output"))
+		assertExtracted(t, filepath.Join(out, "traces"))
+		assert.FileExists(t, filepath.Join(out, zipArtefact.name))
+		assert.FileExists(t, filepath.Join(out, tarArtefact.name))
+		assert.FileExists(t, filepath.Join(out, textArtefact.name))
+		for i := range report.Artefacts {
+			switch report.Artefacts[i].Name {
+			case zipArtefact.name:
+				assert.Equal(t, filepath.Join(out, "output"), report.Artefacts[i].ExtractedTo)
+			case tarArtefact.name:
+				assert.Equal(t, filepath.Join(out, "traces"), report.Artefacts[i].ExtractedTo)
+			default:
+				assert.Empty(t, report.Artefacts[i].ExtractedTo)
+			}
+		}
+		require.NoError(t, VerifyDirectoryAgainstManifest(context.Background(), out, filepath.Join(out, DefaultManifestFileName)))
+	})
+	t.Run("delete archives", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithExtractArchives(true, true), WithManifest(true))
+		require.NoError(t, err)
+		assert.Equal(t, len(artefacts), report.Downloaded)
+		assertExtracted(t, filepath.Join(out, "output"))
+		assertExtracted(t, filepath.Join(out, "traces"))
+		assert.NoFileExists(t, filepath.Join(out, zipArtefact.name))
+		assert.NoFileExists(t, filepath.Join(out, tarArtefact.name))
+		assert.FileExists(t, filepath.Join(out, textArtefact.name))
+		manifest, err := ReadManifest(filepath.Join(out, DefaultManifestFileName))
+		require.NoError(t, err)
+		require.Len(t, manifest.Artefacts, 1)
+		assert.Equal(t, textArtefact.name, manifest.Artefacts[0].Name)
+	})
+	t.Run("no extraction by default", func(t *testing.T) {
+		out := t.TempDir()
+		require.NoError(t, newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefacts(context.Background(), faker.Word(), out))
+		assert.NoDirExists(t, filepath.Join(out, "output"))
+		assert.NoDirExists(t, filepath.Join(out, "traces"))
+	})
+	t.Run("zip slip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		evil := newTestArchiveArtefact(t, tmpDir, "evil.zip", newTestZip(t, map[string]string{"../../evil.txt": faker.Paragraph()}))
+		out := filepath.Join(t.TempDir(), "out")
+		err := newTestArtefactsManager(t, []*testArtefact{evil}, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), out, WithExtractArchives(true, false))
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(out), "evil.txt"))
+		assert.NoFileExists(t, filepath.Join(out, "evil.zip"))
+	})
+	t.Run("decompression bomb", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		bomb := newTestArchiveArtefact(t, tmpDir, "bomb.zip", newTestZip(t, map[string]string{"bomb.bin": string(make([]byte, 10000))}))
+		out := t.TempDir()
+		err := newTestArtefactsManager(t, []*testArtefact{bomb}, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), out, WithExtractArchives(true, false), WithExtractionLimits(filesystem.NewLimits(1000, 5000, 10, -1, false)))
+		errortest.AssertError(t, err, commonerrors.ErrTooLarge)
+		assert.NoFileExists(t, filepath.Join(out, "bomb", "bomb.bin"))
+	})
+	t.Run("partial extraction", func(t *testing.T) {
+		var b bytes.Buffer
+		w := zip.NewWriter(&b)
+		// entries are extracted in order so that the first one is stored before the second one exceeds the limits.
+		for _, entry := range []struct{ name, content string }{{name: "logs/small.log", content: faker.Word()}, {name: "large.bin", content: string(make([]byte, 10000))}} {
+			entryWriter, err := w.Create(entry.name)
+			require.NoError(t, err)
+			_, err = entryWriter.Write([]byte(entry.content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		archive := newTestArchiveArtefact(t, t.TempDir(), "partial.zip", b.Bytes())
+		out := t.TempDir()
+		err := newTestArtefactsManager(t, []*testArtefact{archive}, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), out, WithExtractArchives(true, false), WithExtractionLimits(filesystem.NewLimits(1000, 5000, 10, -1, false)))
+		errortest.AssertError(t, err, commonerrors.ErrTooLarge)
+		// nothing extracted is left behind.
+		assert.NoDirExists(t, filepath.Join(out, "partial"))
+	})
+	t.Run("colliding entries", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		colliding := []*testArtefact{
+			newTestArchiveArtefact(t, tmpDir, "output.zip", newTestZip(t, entries)),
+			newTestArchiveArtefact(t, tmpDir, "output.tar.gz", newTestTarGz(t, entries)),
+		}
+		report, err := newTestArtefactsManager(t, colliding, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), t.TempDir(), WithExtractArchives(true, false), WithStopOnFirstError(false))
+		errortest.AssertError(t, err, commonerrors.ErrExists)
+		assert.Positive(t, report.Failed)
+	})
+	t.Run("too many files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		archive := newTestArchiveArtefact(t, tmpDir, "many.tgz", newTestTarGz(t, entries))
+		err := newTestArtefactsManager(t, []*testArtefact{archive}, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), t.TempDir(), WithExtractArchives(true, false), WithExtractionLimits(filesystem.NewLimits(1000, 5000, 1, -1, false)))
+		errortest.AssertError(t, err, commonerrors.ErrTooLarge)
+	})
+	t.Run("invalid archive", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		archive := newTestArchiveArtefact(t, tmpDir, "invalid.zip", []byte(faker.Paragraph()))
+		err := newTestArtefactsManager(t, []*testArtefact{archive}, false).DownloadAllJobArtefactsWithOptions(context.Background(), faker.Word(), t.TempDir(), WithExtractArchives(true, false))
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+	})
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+)
+
+// DefaultFollowPollingPeriod is the default period at which the artefacts of a running job are checked for changes.
+const DefaultFollowPollingPeriod = 10 * time.Second
+
+// HasJobCompletedFunc states whether a job has completed e.g. by calling job.IJobManager.HasJobCompleted.
+type HasJobCompletedFunc = func(ctx context.Context, jobName string) (completed bool, err error)
+
+// FollowJobArtefacts downloads the artefacts of a job while it is running. The artefacts are listed periodically (see WithPollingPeriod) and those which are new or whose hash changed are downloaded.
+// Once hasJobCompleted states that the job has completed, a final synchronisation is performed. Only failures happening during the final synchronisation are reported as artefacts may not be complete while the job runs.
+// Artefacts which did not change since they were last downloaded are reported as skipped.
+func (m *ArtefactManager[M, D, L, C]) FollowJobArtefacts(ctx context.Context, jobName string, outputDirectory string, hasJobCompleted HasJobCompletedFunc, opts ...DownloadOption) (report *DownloadReport, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	if hasJobCompleted == nil {
+		err = commonerrors.UndefinedVariable("function to determine whether the job has completed")
+		return
+	}
+
+	session := newDownloadSession(jobName, NewDownloadOptions(opts...))
+	report = session.report
+	start := time.Now()
+	defer func() { report.Duration = time.Since(start) }()
+	err = session.openSink(ctx, outputDirectory)
+	if err != nil {
+		return
+	}
+	err = m.followJobArtefacts(ctx, session, hasJobCompleted)
+	err = collateErrors(err, session.closeSink(ctx))
+	session.flushCache(ctx)
+	return
+}
+
+func (m *ArtefactManager[M, D, L, C]) followJobArtefacts(ctx context.Context, session *downloadSession, hasJobCompleted HasJobCompletedFunc) (err error) {
+	pollingPeriod := session.options.PollingPeriod
+	if pollingPeriod <= 0 {
+		pollingPeriod = DefaultFollowPollingPeriod
+	}
+	downloadedHashes := map[string]string{}
+	for {
+		completed, subErr := hasJobCompleted(ctx, session.jobName)
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, subErr, "could not determine whether job [%v] has completed", session.jobName)
+			return
+		}
+		// the job state is checked before listing artefacts so that the last synchronisation happens once all artefacts have been produced.
+		session.provisional = !completed
+		err = m.syncJobArtefacts(ctx, session, downloadedHashes)
+		if completed {
+			return
+		}
+		if err != nil && session.options.Logger != nil {
+			session.options.Logger.LogError(fmt.Sprintf("artefacts of job [%v] could not all be downloaded yet: %v", session.jobName, err))
+		}
+		parallelisation.SleepWithContext(ctx, pollingPeriod)
+		err = parallelisation.DetermineContextError(ctx)
+		if err != nil {
+			return
+		}
+	}
+}
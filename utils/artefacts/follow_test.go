@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client/client"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+func TestFollowJobArtefacts(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+	}
+	updatedContent := faker.Paragraph()
+	// the job produces an additional artefact at every poll and updates the first artefact before completing.
+	polls := 0
+	hasJobCompleted := func(context.Context, string) (bool, error) {
+		polls++
+		if polls == len(artefacts) {
+			require.NoError(t, filesystem.WriteFile(artefacts[0].path, []byte(updatedContent), 0777))
+		}
+		return polls > len(artefacts), nil
+	}
+	listArtefacts := func(ctx context.Context, job string) (*client.ArtefactManagerCollection, *http.Response, error) {
+		return testGetArtefactManagers(t, artefacts[:min(polls, len(artefacts))], true)(ctx, job)
+	}
+	m := NewArtefactManager(listArtefacts, nil, testGetArtefactManager(t, artefacts), testGetOutputArtefact(t, artefacts))
+
+	out := t.TempDir()
+	reporter := &testProgressReporter{}
+	report, err := m.FollowJobArtefacts(context.Background(), faker.Word(), out, hasJobCompleted, WithPollingPeriod(time.Millisecond), WithManifest(true), WithProgress(reporter))
+	require.NoError(t, err)
+	var downloads []string
+	for i := range reporter.events {
+		if reporter.events[i].event == "finish" {
+			downloads = append(downloads, reporter.events[i].artefact.Name)
+		}
+	}
+	assert.Equal(t, len(artefacts)+1, polls)
+	// every artefact is downloaded once except the first one which changed.
+	assert.ElementsMatch(t, []string{artefacts[0].name, artefacts[1].name, artefacts[2].name, artefacts[0].name}, downloads)
+	// none of the artefacts changed after they were last downloaded so they were all skipped during the final synchronisation.
+	assert.Zero(t, report.Downloaded)
+	assert.Equal(t, len(artefacts), report.Skipped)
+	assert.Zero(t, report.Failed)
+	require.Len(t, report.Artefacts, len(artefacts))
+	for i := range report.Artefacts {
+		assert.Equal(t, DownloadStatusSkipped, report.Artefacts[i].Status)
+		assert.Equal(t, filepath.Join(out, report.Artefacts[i].Name), report.Artefacts[i].Destination)
+		assert.NotZero(t, report.Artefacts[i].Size)
+	}
+	for i := range artefacts {
+		expectedContents, err := filesystem.ReadFile(artefacts[i].path)
+		require.NoError(t, err)
+		actualContents, err := filesystem.ReadFile(filepath.Join(out, artefacts[i].name))
+		require.NoError(t, err)
+		assert.Equal(t, expectedContents, actualContents)
+	}
+	actualContents, err := filesystem.ReadFile(filepath.Join(out, artefacts[0].name))
+	require.NoError(t, err)
+	assert.Equal(t, updatedContent, string(actualContents))
+	manifest, err := ReadManifest(filepath.Join(out, DefaultManifestFileName))
+	require.NoError(t, err)
+	assert.Len(t, manifest.Artefacts, len(artefacts))
+	require.NoError(t, VerifyDirectoryAgainstManifest(context.Background(), out, filepath.Join(out, DefaultManifestFileName)))
+}
+
+func TestFollowJobArtefactsFailures(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, true),
+	}
+	m := newTestArtefactsManager(t, artefacts, false)
+	t.Run("failures are only reported once the job completes", func(t *testing.T) {
+		polls := 0
+		report, err := m.FollowJobArtefacts(context.Background(), faker.Word(), t.TempDir(), func(context.Context, string) (bool, error) {
+			polls++
+			return polls > 2, nil
+		}, WithPollingPeriod(time.Millisecond))
+		require.Error(t, err)
+		assert.Equal(t, 3, polls)
+		// the artefact which could be downloaded did not change afterwards.
+		assert.Equal(t, 1, report.Skipped)
+		assert.Equal(t, 1, report.Failed)
+		assert.Len(t, report.Artefacts, len(artefacts))
+	})
+	t.Run("job state cannot be determined", func(t *testing.T) {
+		_, err := m.FollowJobArtefacts(context.Background(), faker.Word(), t.TempDir(), func(context.Context, string) (bool, error) {
+			return false, commonerrors.ErrUnavailable
+		})
+		errortest.AssertError(t, err, commonerrors.ErrUnexpected)
+	})
+	t.Run("missing job state function", func(t *testing.T) {
+		_, err := m.FollowJobArtefacts(context.Background(), faker.Word(), t.TempDir(), nil)
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+	})
+	t.Run("cancelled while following", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.FollowJobArtefacts(ctx, faker.Word(), t.TempDir(), func(context.Context, string) (bool, error) {
+			cancel()
+			return false, nil
+		}, WithPollingPeriod(time.Millisecond))
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+	})
+}
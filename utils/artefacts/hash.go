@@ -0,0 +1,82 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+)
+
+const (
+	// HashSha512 refers to the SHA-512 hashing algorithm. Other algorithms are referred to using the references defined in golang-utils hashing package e.g. hashing.HashSha256.
+	HashSha512 = transfer.HashSha512
+	// DefaultHashAlgorithm is the algorithm assumed for hashes whose algorithm cannot be inferred.
+	DefaultHashAlgorithm = transfer.DefaultHashAlgorithm
+)
+
+// SupportedHashAlgorithms lists the hashing algorithms which can be used to verify artefacts, from the weakest to the strongest.
+// Hashes which are not prefixed with their algorithm e.g. `sha256:1a2b…` are identified by their length, 64-character ones being considered SHA-256.
+var SupportedHashAlgorithms = transfer.SupportedHashAlgorithms
+
+// hashingWriter calculates the digest of the content written to it so that content can be hashed while it is copied.
+type hashingWriter struct {
+	writer *io.PipeWriter
+	done   chan struct{}
+	digest string
+	err    error
+}
+
+// newHashingWriter returns a writer calculating a digest using algorithm. Sum must be called once everything has been written.
+func newHashingWriter(ctx context.Context, algorithm string) (w *hashingWriter, err error) {
+	h, err := transfer.NewHashingAlgorithm(algorithm)
+	if err != nil {
+		return
+	}
+	reader, writer := io.Pipe()
+	w = &hashingWriter{writer: writer, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		w.digest, w.err = h.CalculateWithContext(ctx, reader)
+		// any further write fails rather than waits if the content is no longer read.
+		_ = reader.CloseWithError(w.err)
+	}()
+	return
+}
+
+func (w *hashingWriter) Write(p []byte) (int, error) {
+	return w.writer.Write(p)
+}
+
+// Sum waits for the content written to be hashed and returns its hexadecimal digest. Nothing can be written afterwards.
+func (w *hashingWriter) Sum() (digest string, err error) {
+	_ = w.writer.Close()
+	<-w.done
+	digest = w.digest
+	if w.err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrUnexpected, w.err, "could not calculate hash")
+	}
+	return
+}
+
+// checkMinimumHashAlgorithm verifies that algorithm is at least as strong as minimum. Any algorithm is accepted if minimum is empty.
+func checkMinimumHashAlgorithm(algorithm, minimum string) (err error) {
+	if strings.TrimSpace(minimum) == "" {
+		return
+	}
+	minimumAlgorithm, err := transfer.DetermineHashAlgorithm(minimum)
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrInvalid, err, "invalid minimum hashing algorithm")
+		return
+	}
+	if transfer.HashAlgorithmStrength(algorithm) < transfer.HashAlgorithmStrength(minimumAlgorithm) {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "artefact hashing algorithm [%v] is weaker than the minimum accepted [%v]", algorithm, minimumAlgorithm)
+	}
+	return
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/hashing"
+)
+
+func TestHashingWriter(t *testing.T) {
+	content := faker.Paragraph()
+	for i := range SupportedHashAlgorithms {
+		algorithm := SupportedHashAlgorithms[i]
+		t.Run(algorithm, func(t *testing.T) {
+			w, err := newHashingWriter(context.Background(), algorithm)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(content))
+			require.NoError(t, err)
+			actual, err := w.Sum()
+			require.NoError(t, err)
+			h, err := transfer.NewHashingAlgorithm(algorithm)
+			require.NoError(t, err)
+			expected, err := h.Calculate(strings.NewReader(content))
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+			_, err = w.Write([]byte(content))
+			require.Error(t, err)
+		})
+	}
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		w, err := newHashingWriter(ctx, hashing.HashSha256)
+		require.NoError(t, err)
+		_, _ = w.Write([]byte(content))
+		_, err = w.Sum()
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+	})
+}
+
+func TestCheckMinimumHashAlgorithm(t *testing.T) {
+	require.NoError(t, checkMinimumHashAlgorithm(hashing.HashMd5, ""))
+	require.NoError(t, checkMinimumHashAlgorithm(hashing.HashSha256, hashing.HashSha256))
+	require.NoError(t, checkMinimumHashAlgorithm(hashing.HashBlake2256, "sha-256"))
+	require.NoError(t, checkMinimumHashAlgorithm(HashSha512, hashing.HashSha1))
+	errortest.AssertError(t, checkMinimumHashAlgorithm(hashing.HashSha1, hashing.HashSha256), commonerrors.ErrInvalid)
+	errortest.AssertError(t, checkMinimumHashAlgorithm(hashing.HashSha256, "sha512"), commonerrors.ErrInvalid)
+	errortest.AssertError(t, checkMinimumHashAlgorithm(hashing.HashSha256, faker.Word()), commonerrors.ErrInvalid)
+}
+
+func TestDownloadWithHashAlgorithms(t *testing.T) {
+	tmpDir := t.TempDir()
+	sha256Artefact := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	blake2Artefact := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	blake2Artefact.hashAlgorithm = hashing.HashBlake2256
+	md5Artefact := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	md5Artefact.hashAlgorithm = hashing.HashMd5
+	artefacts := []*testArtefact{sha256Artefact, blake2Artefact, md5Artefact}
+
+	t.Run("prefixed hashes are verified", func(t *testing.T) {
+		m := newTestArtefactsManager(t, artefacts, false)
+		out := t.TempDir()
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithManifest(true))
+		require.NoError(t, err)
+		assert.Equal(t, len(artefacts), report.Downloaded)
+		for i := range report.Artefacts {
+			switch report.Artefacts[i].Name {
+			case blake2Artefact.name:
+				assert.Equal(t, hashing.HashBlake2256, report.Artefacts[i].HashAlgorithm)
+			case md5Artefact.name:
+				assert.Equal(t, hashing.HashMd5, report.Artefacts[i].HashAlgorithm)
+			default:
+				assert.Equal(t, hashing.HashSha256, report.Artefacts[i].HashAlgorithm)
+			}
+		}
+		require.NoError(t, VerifyDirectoryAgainstManifest(context.Background(), out, filepath.Join(out, DefaultManifestFileName)))
+		checksums, err := filesystem.ReadFile(filepath.Join(out, DefaultChecksumFileName))
+		require.NoError(t, err)
+		assert.Contains(t, string(checksums), sha256Artefact.name)
+		assert.NotContains(t, string(checksums), blake2Artefact.name)
+		assert.NotContains(t, string(checksums), md5Artefact.name)
+	})
+	t.Run("weak hashes are rejected", func(t *testing.T) {
+		m := newTestArtefactsManager(t, artefacts, false)
+		out := t.TempDir()
+		report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithStopOnFirstError(false), WithMinimumHashAlgorithm(hashing.HashSha256))
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+		assert.Equal(t, 2, report.Downloaded)
+		require.Len(t, report.FailedArtefacts(), 1)
+		assert.Equal(t, md5Artefact.name, report.FailedArtefacts()[0].Name)
+		assert.NoFileExists(t, filepath.Join(out, md5Artefact.name))
+	})
+}
@@ -7,6 +7,8 @@ package artefacts
 
 import (
 	"context"
+	"io"
+	"iter"
 
 	"github.com/ARM-software/golang-utils/utils/collection/pagination"
 )
@@ -29,6 +31,11 @@ type IArtefactManager[
 	DownloadJobArtefactWithTree(ctx context.Context, jobName string, maintainTreeLocation bool, outputDirectory string, artefactManager M) error
 	// ListJobArtefacts lists all artefact managers associated with a particular job.
 	ListJobArtefacts(ctx context.Context, jobName string) (pagination.IPaginatorAndPageFetcher, error)
+	// IterateJobArtefacts iterates over the artefact managers of a particular job, resolving links to artefact managers as needed.
+	// Errors resolving a particular artefact manager are yielded without stopping the iteration.
+	IterateJobArtefacts(ctx context.Context, jobName string) iter.Seq2[M, error]
+	// ListJobArtefactManagers returns the managers of all the artefacts of a particular job e.g. to inspect their size or metadata before downloading them.
+	ListJobArtefactManagers(ctx context.Context, jobName string) ([]M, error)
 	// DownloadAllJobArtefacts downloads all the artefacts produced for a particular job and puts them in an output directory as a flat list.
 	DownloadAllJobArtefacts(ctx context.Context, jobName string, outputDirectory string) error
 	// DownloadAllJobArtefactsWithTree downloads all the artefacts produced for a particular job and puts them in an output directory.
@@ -36,6 +43,15 @@ type IArtefactManager[
 	DownloadAllJobArtefactsWithTree(ctx context.Context, jobName string, maintainTreeStructure bool, outputDirectory string) error
 	// DownloadAllJobArtefactsWithOptions downloads all the artefacts produced for a particular job and puts them in an output directory, specify some download option.
 	DownloadAllJobArtefactsWithOptions(ctx context.Context, jobName string, outputDirectory string, opts ...DownloadOption) (err error)
+	// DownloadAllJobArtefactsWithReport downloads all the artefacts produced for a particular job similarly to DownloadAllJobArtefactsWithOptions but also returns a report describing what happened to each artefact.
+	DownloadAllJobArtefactsWithReport(ctx context.Context, jobName string, outputDirectory string, opts ...DownloadOption) (report *DownloadReport, err error)
+	// PlanJobArtefactsDownload determines which artefacts DownloadAllJobArtefactsWithOptions would download given the same options, where they would be stored and how much disk space they would need, without downloading any content.
+	PlanJobArtefactsDownload(ctx context.Context, jobName string, outputDirectory string, opts ...DownloadOption) (plan *DownloadPlan, err error)
+	// DownloadArtefactsForJobs downloads the artefacts of several jobs e.g. all the jobs of a build matrix, each into its own directory of outputDirectory named after the job (see WithJobDirectoryTemplate), and returns a report for each job.
+	// Jobs are processed concurrently (see WithConcurrency) and a failure for one job does not prevent the artefacts of other jobs from being downloaded unless specified otherwise (see WithStopOnFirstJobError).
+	DownloadArtefactsForJobs(ctx context.Context, jobNames []string, outputDirectory string, opts ...DownloadOption) (report *JobsDownloadReport, err error)
+	// FollowJobArtefacts downloads the artefacts of a job while it is running, downloading new or changed artefacts periodically until hasJobCompleted states that the job has completed and a final synchronisation is performed.
+	FollowJobArtefacts(ctx context.Context, jobName string, outputDirectory string, hasJobCompleted HasJobCompletedFunc, opts ...DownloadOption) (report *DownloadReport, err error)
 }
 
 type ILinkData interface {
@@ -65,3 +81,23 @@ type IManager interface {
 	GetExtraMetadata() map[string]string
 	HasExtraMetadata() bool
 }
+
+// IArtefactSink defines a destination where downloaded artefacts are stored e.g. a directory, an archive or a store.
+type IArtefactSink interface {
+	// Location describes where an artefact stored at relativePath ends up e.g. the path of the corresponding file.
+	Location(relativePath string) string
+	// Create returns a writer to store an artefact at relativePath, a slash-separated path relative to the root of the sink.
+	// The artefact should not be considered stored until the writer is committed.
+	Create(ctx context.Context, relativePath string) (IArtefactWriter, error)
+	// Close finalises the sink once all artefacts have been stored.
+	Close(ctx context.Context) error
+}
+
+// IArtefactWriter defines a writer of an artefact's content into a sink.
+type IArtefactWriter interface {
+	io.Writer
+	// Commit is called once the artefact has been fully written and verified so that it gets persisted.
+	Commit(ctx context.Context) error
+	// Abort is called instead of Commit if the artefact could not be downloaded or verified so that it gets discarded.
+	Abort() error
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+	"github.com/ARM-software/golang-utils/utils/reflection"
+)
+
+const (
+	// DefaultConcurrency is the default maximum number of artefacts downloaded at the same time when downloading the artefacts of several jobs.
+	DefaultConcurrency = 4
+	// DefaultJobDirectoryTemplate is the default template used for naming the directory holding the artefacts of each job i.e. `<output directory>/<job name>`.
+	DefaultJobDirectoryTemplate = "{{.Job}}"
+)
+
+// JobDirectoryTemplateData describes what can be used in a template naming the directory holding the artefacts of a job (see WithJobDirectoryTemplate).
+type JobDirectoryTemplateData struct {
+	// Job is the name of the job.
+	Job string
+	// Index is the position of the job in the list of jobs, starting from 0.
+	Index int
+}
+
+// JobDownloadReport describes the outcome of the download of the artefacts of one of several jobs.
+type JobDownloadReport struct {
+	// Job is the name of the job.
+	Job string
+	// OutputDirectory is the directory the artefacts of the job were downloaded to.
+	OutputDirectory string
+	// Report describes what happened to each artefact of the job.
+	Report *DownloadReport
+	// Err is the reason why the artefacts of the job could not all be downloaded, if any.
+	Err error
+}
+
+// HasFailed states whether the artefacts of the job could not all be downloaded.
+func (r *JobDownloadReport) HasFailed() bool {
+	return r.Err != nil || r.Report.HasFailures()
+}
+
+// JobsDownloadReport describes the outcome of the download of the artefacts of several jobs.
+type JobsDownloadReport struct {
+	// Jobs lists what happened to the artefacts of each job, in the order the jobs were provided.
+	Jobs []JobDownloadReport
+	// Downloaded is the number of artefacts successfully downloaded across all jobs.
+	Downloaded int
+	// Skipped is the number of artefacts which were skipped across all jobs.
+	Skipped int
+	// Failed is the number of artefacts which could not be downloaded across all jobs.
+	Failed int
+	// FailedJobs is the number of jobs whose artefacts could not all be downloaded.
+	FailedJobs int
+	// TotalSize is the total number of bytes downloaded across all jobs.
+	TotalSize int64
+	// Duration is the time it took to process all the jobs.
+	Duration time.Duration
+}
+
+// tally determines the totals of the report from the reports of each job.
+func (r *JobsDownloadReport) tally() {
+	r.Downloaded, r.Skipped, r.Failed, r.FailedJobs, r.TotalSize = 0, 0, 0, 0, 0
+	for i := range r.Jobs {
+		if r.Jobs[i].HasFailed() {
+			r.FailedJobs++
+		}
+		jobReport := r.Jobs[i].Report
+		if jobReport == nil {
+			continue
+		}
+		r.Downloaded += jobReport.Downloaded
+		r.Skipped += jobReport.Skipped
+		r.Failed += jobReport.Failed
+		r.TotalSize += jobReport.TotalSize
+	}
+}
+
+// HasFailures states whether the artefacts of some jobs could not all be downloaded.
+func (r *JobsDownloadReport) HasFailures() bool {
+	return r != nil && r.FailedJobs > 0
+}
+
+// FailedJobReports returns the reports of all the jobs whose artefacts could not all be downloaded.
+func (r *JobsDownloadReport) FailedJobReports() (failed []JobDownloadReport) {
+	if r == nil {
+		return
+	}
+	for i := range r.Jobs {
+		if r.Jobs[i].HasFailed() {
+			failed = append(failed, r.Jobs[i])
+		}
+	}
+	return
+}
+
+// Errors returns all the errors encountered while downloading the artefacts of all jobs joined together or nil if there were none.
+func (r *JobsDownloadReport) Errors() error {
+	var errs []error
+	for _, j := range r.FailedJobReports() {
+		if j.Err != nil {
+			errs = append(errs, j.Err)
+		} else if subErr := j.Report.Errors(); subErr != nil {
+			errs = append(errs, subErr)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return commonerrors.Join(errs...)
+}
+
+// determineJobDirectories determines the directory holding the artefacts of each job from a template. Each job must be given a distinct directory within outputDirectory which does not contain the directory of another job.
+func determineJobDirectories(outputDirectory string, jobNames []string, directoryTemplate string) (directories []string, err error) {
+	if reflection.IsEmpty(directoryTemplate) {
+		err = commonerrors.UndefinedVariable("job directory template")
+		return
+	}
+	tmpl, err := template.New("job directory").Option("missingkey=error").Parse(directoryTemplate)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "invalid job directory template [%v]", directoryTemplate)
+		return
+	}
+	directories = make([]string, len(jobNames))
+	for i := range jobNames {
+		if reflection.IsEmpty(jobNames[i]) {
+			err = commonerrors.UndefinedVariable("job name")
+			return
+		}
+		var name strings.Builder
+		subErr := tmpl.Execute(&name, JobDirectoryTemplateData{Job: jobNames[i], Index: i})
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, subErr, "could not determine the directory of job [%v]", jobNames[i])
+			return
+		}
+		relativePath, subErr := sanitiseRelativePath(name.String())
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, subErr, "invalid directory [%v] for job [%v]", name.String(), jobNames[i])
+			return
+		}
+		if relativePath == "" {
+			err = commonerrors.Newf(commonerrors.ErrInvalid, "the directory of job [%v] must not be the output directory", jobNames[i])
+			return
+		}
+		directories[i] = filepath.Join(outputDirectory, relativePath)
+		for j := 0; j < i; j++ {
+			if transfer.IsWithinDirectory(directories[j], directories[i]) || transfer.IsWithinDirectory(directories[i], directories[j]) {
+				err = commonerrors.Newf(commonerrors.ErrConflict, "jobs [%v] and [%v] would have their artefacts downloaded to the same directory [%v]", jobNames[j], jobNames[i], directories[i])
+				return
+			}
+		}
+	}
+	return
+}
+
+// DownloadArtefactsForJobs downloads the artefacts of several jobs e.g. all the jobs of a build matrix, into their own directory of outputDirectory (see WithJobDirectoryTemplate).
+func (m *ArtefactManager[M, D, L, C]) DownloadArtefactsForJobs(ctx context.Context, jobNames []string, outputDirectory string, opts ...DownloadOption) (report *JobsDownloadReport, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	report = &JobsDownloadReport{}
+	start := time.Now()
+	defer func() { report.Duration = time.Since(start) }()
+	options := NewDownloadOptions(opts...)
+	if options.Sink != nil || !reflection.IsEmpty(options.ArchivePath) {
+		err = commonerrors.New(commonerrors.ErrInvalid, "the artefacts of several jobs cannot be downloaded to a single sink or archive")
+		return
+	}
+	directories, err := determineJobDirectories(outputDirectory, jobNames, options.JobDirectoryTemplate)
+	if err != nil {
+		return
+	}
+	// the options and the rate limiter are shared by all jobs so that the rate limit and the cache, if any, are too.
+	jobOptions := *options
+	limiter := newRateLimiter(options.RateLimit)
+	if options.Progress != nil {
+		jobOptions.Progress = newSerialisedProgressReporter(options.Progress)
+	}
+
+	report.Jobs = make([]JobDownloadReport, len(jobNames))
+	// jobs are cancelled rather than aborted with the cause of the first failure so that their report does not wrongly state they failed for the same reason.
+	jobsCtx, cancelJobs := context.WithCancel(ctx)
+	defer cancelJobs()
+	var group errgroup.Group
+	group.SetLimit(max(options.Concurrency, 1))
+	for i := range jobNames {
+		report.Jobs[i] = JobDownloadReport{Job: jobNames[i], OutputDirectory: directories[i]}
+		group.Go(func() error {
+			jobReport, subErr := m.downloadJobArtefactsForJobs(jobsCtx, jobNames[i], directories[i], &jobOptions, limiter)
+			report.Jobs[i].Report = jobReport
+			if subErr == nil {
+				return nil
+			}
+			subErr = commonerrors.DescribeCircumstanceAndKeepTypef(subErr, "could not download the artefacts of job [%v]", jobNames[i])
+			report.Jobs[i].Err = subErr
+			if options.Logger != nil {
+				options.Logger.LogError(subErr)
+			}
+			if options.StopOnFirstJobError {
+				cancelJobs()
+				return subErr
+			}
+			return nil
+		})
+	}
+	err = group.Wait()
+	report.tally()
+	if err == nil {
+		err = report.Errors()
+	}
+	return
+}
+
+func (m *ArtefactManager[M, D, L, C]) downloadJobArtefactsForJobs(ctx context.Context, jobName string, outputDirectory string, options *DownloadOptions, limiter *rateLimiter) (report *DownloadReport, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		report = newDownloadReport(jobName)
+		return
+	}
+	report, err = m.downloadAllJobArtefactsWithReport(ctx, jobName, outputDirectory, options, limiter)
+	if err == nil && options.Logger != nil {
+		options.Logger.Log(fmt.Sprintf("downloaded the artefacts of job %s", jobName))
+	}
+	return
+}
+
+// serialisedProgressReporter serialises the calls made to a reporter by the downloads of several jobs performed at the same time.
+type serialisedProgressReporter struct {
+	mu       sync.Mutex
+	reporter IProgressReporter
+}
+
+func newSerialisedProgressReporter(reporter IProgressReporter) IProgressReporter {
+	return &serialisedProgressReporter{reporter: reporter}
+}
+
+func (r *serialisedProgressReporter) OnStart(artefact ArtefactProgress, job JobProgress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reporter.OnStart(artefact, job)
+}
+
+func (r *serialisedProgressReporter) OnProgress(artefact ArtefactProgress, job JobProgress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reporter.OnProgress(artefact, job)
+}
+
+func (r *serialisedProgressReporter) OnFinish(artefact ArtefactProgress, job JobProgress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reporter.OnFinish(artefact, job)
+}
+
+func (r *serialisedProgressReporter) OnError(artefact ArtefactProgress, job JobProgress, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reporter.OnError(artefact, job, err)
+}
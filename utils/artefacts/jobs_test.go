@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client/client"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+// newTestJobsArtefactsManager returns a manager for which listing the artefacts of failingJob fails.
+func newTestJobsArtefactsManager(t *testing.T, artefacts []*testArtefact, failingJob string) IArtefactManager[*client.ArtefactManagerItem, *client.HalLinkData] {
+	getFirstPage := testGetArtefactManagers(t, artefacts, true)
+	return NewArtefactManager(func(ctx context.Context, job string) (*client.ArtefactManagerCollection, *http.Response, error) {
+		if job == failingJob {
+			return nil, nil, commonerrors.ErrUnavailable
+		}
+		return getFirstPage(ctx, job)
+	}, nil, testGetArtefactManager(t, artefacts), testGetOutputArtefact(t, artefacts))
+}
+
+func TestDownloadArtefactsForJobs(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+	}
+	jobs := []string{"matrix-linux", "matrix-windows", "matrix-macos"}
+
+	assertDownloaded := func(t *testing.T, directory string) {
+		t.Helper()
+		for i := range artefacts {
+			expected, err := filesystem.ReadFile(artefacts[i].path)
+			require.NoError(t, err)
+			actual, err := filesystem.ReadFile(filepath.Join(directory, artefacts[i].name))
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		}
+	}
+
+	t.Run("all jobs", func(t *testing.T) {
+		out := t.TempDir()
+		reporter := &testProgressReporter{}
+		report, err := newTestJobsArtefactsManager(t, artefacts, "").DownloadArtefactsForJobs(context.Background(), jobs, out, WithConcurrency(2), WithProgress(reporter))
+		require.NoError(t, err)
+		require.NotNil(t, report)
+		require.Len(t, report.Jobs, len(jobs))
+		for i := range jobs {
+			assert.Equal(t, jobs[i], report.Jobs[i].Job)
+			assert.Equal(t, filepath.Join(out, jobs[i]), report.Jobs[i].OutputDirectory)
+			require.NotNil(t, report.Jobs[i].Report)
+			assert.Equal(t, jobs[i], report.Jobs[i].Report.Job)
+			assert.Equal(t, len(artefacts), report.Jobs[i].Report.Downloaded)
+			assert.False(t, report.Jobs[i].HasFailed())
+			assertDownloaded(t, filepath.Join(out, jobs[i]))
+		}
+		assert.Equal(t, len(jobs)*len(artefacts), report.Downloaded)
+		assert.Zero(t, report.Failed)
+		assert.Zero(t, report.FailedJobs)
+		assert.False(t, report.HasFailures())
+		assert.Positive(t, report.TotalSize)
+		assert.Equal(t, len(jobs)*len(artefacts), reporter.count("finish"))
+	})
+	t.Run("directory template", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestJobsArtefactsManager(t, artefacts, "").DownloadArtefactsForJobs(context.Background(), jobs, out, WithJobDirectoryTemplate("builds/{{.Index}}-{{.Job}}"))
+		require.NoError(t, err)
+		for i := range jobs {
+			directory := filepath.Join(out, "builds", fmt.Sprintf("%d-%s", i, jobs[i]))
+			assert.Equal(t, directory, report.Jobs[i].OutputDirectory)
+			assertDownloaded(t, directory)
+		}
+	})
+	t.Run("failing job", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestJobsArtefactsManager(t, artefacts, jobs[1]).DownloadArtefactsForJobs(context.Background(), jobs, out, WithConcurrency(1))
+		errortest.AssertError(t, err, commonerrors.ErrUnexpected)
+		require.NotNil(t, report)
+		assert.True(t, report.HasFailures())
+		assert.Equal(t, 1, report.FailedJobs)
+		assert.Equal(t, 2*len(artefacts), report.Downloaded)
+		failed := report.FailedJobReports()
+		require.Len(t, failed, 1)
+		assert.Equal(t, jobs[1], failed[0].Job)
+		errortest.AssertError(t, failed[0].Err, commonerrors.ErrUnexpected)
+		assertDownloaded(t, filepath.Join(out, jobs[0]))
+		assertDownloaded(t, filepath.Join(out, jobs[2]))
+	})
+	t.Run("stop on first job error", func(t *testing.T) {
+		out := t.TempDir()
+		report, err := newTestJobsArtefactsManager(t, artefacts, jobs[0]).DownloadArtefactsForJobs(context.Background(), jobs, out, WithConcurrency(1), WithStopOnFirstJobError(true))
+		require.Error(t, err)
+		require.NotNil(t, report)
+		assert.Equal(t, len(jobs), report.FailedJobs)
+		assert.Zero(t, report.Downloaded)
+		errortest.AssertError(t, report.Jobs[0].Err, commonerrors.ErrUnexpected)
+		for i := 1; i < len(jobs); i++ {
+			errortest.AssertError(t, report.Jobs[i].Err, commonerrors.ErrCancelled)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		manager := newTestJobsArtefactsManager(t, artefacts, "")
+		out := t.TempDir()
+		_, err := manager.DownloadArtefactsForJobs(context.Background(), []string{jobs[0], jobs[0]}, out)
+		errortest.AssertError(t, err, commonerrors.ErrConflict)
+		_, err = manager.DownloadArtefactsForJobs(context.Background(), []string{jobs[0], ""}, out)
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+		_, err = manager.DownloadArtefactsForJobs(context.Background(), jobs, out, WithJobDirectoryTemplate("builds"))
+		errortest.AssertError(t, err, commonerrors.ErrConflict)
+		_, err = manager.DownloadArtefactsForJobs(context.Background(), jobs, out, WithJobDirectoryTemplate("../{{.Job}}"))
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+		_, err = manager.DownloadArtefactsForJobs(context.Background(), jobs, out, WithJobDirectoryTemplate("{{.Unknown}}"))
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+		_, err = manager.DownloadArtefactsForJobs(context.Background(), jobs, out, WithArchive(ArchiveFormatZip, filepath.Join(out, "artefacts.zip")))
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+		empty, err := filesystem.IsEmpty(out)
+		require.NoError(t, err)
+		assert.True(t, empty)
+	})
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/hashing"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+)
+
+const (
+	// DefaultManifestFileName is the name of the JSON manifest written beside downloaded artefacts.
+	DefaultManifestFileName = "artefacts-manifest.json"
+	// DefaultChecksumFileName is the name of the checksum file written beside downloaded artefacts. It can be checked using `sha256sum -c`.
+	DefaultChecksumFileName = "artefacts.sha256"
+)
+
+// ManifestEntry describes an artefact recorded in a manifest.
+type ManifestEntry struct {
+	// Name is the name of the artefact.
+	Name string `json:"name"`
+	// Title is the title of the artefact, if any.
+	Title string `json:"title,omitempty"`
+	// RelativePath is the slash-separated path of the artefact relative to the directory it was downloaded to.
+	RelativePath string `json:"relativePath"`
+	// Size is the size in bytes of the artefact.
+	Size int64 `json:"size"`
+	// Hash is the verified hexadecimal digest of the artefact.
+	Hash string `json:"hash"`
+	// HashAlgorithm is the algorithm Hash was computed with. SHA-256 is assumed if empty.
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+	// ExtraMetadata is any additional metadata the service provided about the artefact.
+	ExtraMetadata map[string]string `json:"extraMetadata,omitempty"`
+}
+
+// Manifest records which artefacts were downloaded from a job.
+type Manifest struct {
+	// Job is the name of the job the artefacts come from.
+	Job string `json:"job"`
+	// Created is the time the manifest was generated.
+	Created time.Time `json:"created"`
+	// Artefacts lists the artefacts which were successfully downloaded.
+	Artefacts []ManifestEntry `json:"artefacts"`
+}
+
+func newManifestEntry[M IManager](item M, result *ArtefactReport) (entry ManifestEntry) {
+	entry = ManifestEntry{
+		Name:          result.Name,
+		RelativePath:  result.RelativePath,
+		Size:          result.Size,
+		Hash:          result.Hash,
+		HashAlgorithm: result.HashAlgorithm,
+	}
+	if item.HasTitle() {
+		entry.Title = item.GetTitle()
+	}
+	if item.HasExtraMetadata() {
+		entry.ExtraMetadata = item.GetExtraMetadata()
+	}
+	return
+}
+
+// manifestRecorder collects the entries of a manifest during a download.
+type manifestRecorder struct {
+	mu       sync.Mutex
+	manifest Manifest
+}
+
+func newManifestRecorder(jobName string) *manifestRecorder {
+	return &manifestRecorder{manifest: Manifest{Job: jobName, Artefacts: []ManifestEntry{}}}
+}
+
+func (r *manifestRecorder) add(entry ManifestEntry) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.manifest.Artefacts {
+		if r.manifest.Artefacts[i].Name == entry.Name {
+			r.manifest.Artefacts[i] = entry
+			return
+		}
+	}
+	r.manifest.Artefacts = append(r.manifest.Artefacts, entry)
+}
+
+// content returns the JSON and checksum forms of the manifest.
+func (r *manifestRecorder) content() (jsonManifest []byte, checksums []byte, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.manifest.Created = time.Now()
+	jsonManifest, checksums, err = r.manifest.marshal()
+	return
+}
+
+// write stores the manifest and its checksum form in a sink.
+func (r *manifestRecorder) write(ctx context.Context, sink IArtefactSink) (err error) {
+	if r == nil {
+		return
+	}
+	jsonManifest, checksums, err := r.content()
+	if err != nil {
+		return
+	}
+	err = writeToSink(ctx, sink, DefaultManifestFileName, jsonManifest)
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not write artefact manifest")
+		return
+	}
+	err = writeToSink(ctx, sink, DefaultChecksumFileName, checksums)
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not write artefact checksums")
+	}
+	return
+}
+
+func writeToSink(ctx context.Context, sink IArtefactSink, relativePath string, content []byte) (err error) {
+	w, err := sink.Create(ctx, relativePath)
+	if err != nil {
+		return
+	}
+	_, err = w.Write(content)
+	if err != nil {
+		_ = w.Abort()
+		return
+	}
+	err = w.Commit(ctx)
+	return
+}
+
+func (e *ManifestEntry) hashAlgorithm() string {
+	if e.HashAlgorithm == "" {
+		return hashing.HashSha256
+	}
+	algorithm, err := transfer.DetermineHashAlgorithm(e.HashAlgorithm)
+	if err != nil {
+		return e.HashAlgorithm
+	}
+	return algorithm
+}
+
+// marshal returns the JSON and `sha256sum`-compatible forms of the manifest.
+func (m *Manifest) marshal() (jsonManifest []byte, checksums []byte, err error) {
+	jsonManifest, err = json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrMarshalling, err, "could not marshal artefact manifest")
+		return
+	}
+	var b strings.Builder
+	for i := range m.Artefacts {
+		// only SHA-256 digests can be checked using `sha256sum`
+		if algorithm := m.Artefacts[i].hashAlgorithm(); algorithm == hashing.HashSha256 {
+			b.WriteString(checksumLine(m.Artefacts[i].Hash, m.Artefacts[i].RelativePath))
+		}
+	}
+	checksums = []byte(b.String())
+	return
+}
+
+// checksumLine formats a line as `sha256sum` does, escaping file names containing backslashes or new lines.
+func checksumLine(hash, relativePath string) string {
+	prefix := ""
+	if strings.ContainsAny(relativePath, "\\\n") {
+		prefix = "\\"
+		relativePath = strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(relativePath)
+	}
+	return fmt.Sprintf("%v%v  %v\n", prefix, strings.ToLower(hash), relativePath)
+}
+
+// ReadManifest reads a manifest previously written alongside downloaded artefacts.
+func ReadManifest(manifestPath string) (manifest *Manifest, err error) {
+	content, err := filesystem.ReadFile(manifestPath)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrNotFound, err, "could not read manifest [%v]", manifestPath)
+		return
+	}
+	manifest = &Manifest{}
+	err = json.Unmarshal(content, manifest)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrMarshalling, err, "could not parse manifest [%v]", manifestPath)
+		manifest = nil
+	}
+	return
+}
+
+// VerifyDirectoryAgainstManifest checks that all the artefacts listed in a manifest are present in directory with the expected size and hash.
+// No call to the service is made. All discrepancies are reported in the returned error.
+func VerifyDirectoryAgainstManifest(ctx context.Context, directory string, manifestPath string) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	manifest, err := ReadManifest(manifestPath)
+	if err != nil {
+		return
+	}
+	var errs []error
+	for i := range manifest.Artefacts {
+		err = parallelisation.DetermineContextError(ctx)
+		if err != nil {
+			return
+		}
+		subErr := verifyManifestEntry(ctx, directory, &manifest.Artefacts[i])
+		if subErr != nil {
+			errs = append(errs, subErr)
+		}
+	}
+	if len(errs) > 0 {
+		err = commonerrors.Join(errs...)
+	}
+	return
+}
+
+func verifyManifestEntry(ctx context.Context, directory string, entry *ManifestEntry) (err error) {
+	relativePath, err := sanitiseRelativePath(entry.RelativePath)
+	if err != nil {
+		return
+	}
+	artefactPath := filepath.Join(directory, relativePath)
+	if !filesystem.Exists(artefactPath) {
+		err = commonerrors.Newf(commonerrors.ErrNotFound, "artefact [%v] could not be found at [%v]", entry.Name, artefactPath)
+		return
+	}
+	actualSize, err := filesystem.GetFileSize(artefactPath)
+	if err != nil {
+		return
+	}
+	if actualSize != entry.Size {
+		err = commonerrors.Newf(commonerrors.ErrCondition, "artefact [%v] size '%v' does not match expected '%v'", entry.Name, actualSize, entry.Size)
+		return
+	}
+	hasher, err := transfer.NewHashingAlgorithm(entry.hashAlgorithm())
+	if err != nil {
+		return
+	}
+	f, err := filesystem.GenericOpen(artefactPath)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not open artefact [%v]", entry.Name)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	actualHash, err := hasher.CalculateWithContext(ctx, f)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not calculate hash of artefact [%v]", entry.Name)
+		return
+	}
+	if !strings.EqualFold(actualHash, entry.Hash) {
+		err = commonerrors.Newf(commonerrors.ErrCondition, "artefact [%v] hash '%v' does not match expected '%v'", entry.Name, actualHash, entry.Hash)
+	}
+	return
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+func TestManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	var artefacts []*testArtefact
+	for i := 0; i < 3; i++ {
+		artefacts = append(artefacts, newTestArtefact(t, tmpDir, faker.Paragraph(), true, false))
+	}
+	m := newTestArtefactsManager(t, artefacts, false)
+	out := t.TempDir()
+	jobName := faker.Word()
+	report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), jobName, out, WithManifest(true))
+	require.NoError(t, err)
+	manifestPath := filepath.Join(out, DefaultManifestFileName)
+	require.FileExists(t, manifestPath)
+	require.FileExists(t, filepath.Join(out, DefaultChecksumFileName))
+
+	manifest, err := ReadManifest(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, jobName, manifest.Job)
+	assert.NotZero(t, manifest.Created)
+	require.Len(t, manifest.Artefacts, len(artefacts))
+	for i := range manifest.Artefacts {
+		entry := manifest.Artefacts[i]
+		assert.Equal(t, report.Artefacts[i].Name, entry.Name)
+		assert.Equal(t, report.Artefacts[i].Name, entry.Title)
+		assert.Equal(t, filepath.Base(report.Artefacts[i].Destination), entry.RelativePath)
+		assert.Equal(t, report.Artefacts[i].Size, entry.Size)
+		assert.Equal(t, report.Artefacts[i].Hash, entry.Hash)
+	}
+	checksums, err := filesystem.ReadFile(filepath.Join(out, DefaultChecksumFileName))
+	require.NoError(t, err)
+	for i := range manifest.Artefacts {
+		assert.Contains(t, string(checksums), fmt.Sprintf("%v  %v\n", manifest.Artefacts[i].Hash, manifest.Artefacts[i].RelativePath))
+	}
+
+	t.Run("valid directory", func(t *testing.T) {
+		require.NoError(t, VerifyDirectoryAgainstManifest(context.Background(), out, manifestPath))
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		errortest.AssertError(t, VerifyDirectoryAgainstManifest(ctx, out, manifestPath), commonerrors.ErrCancelled)
+	})
+	t.Run("missing manifest", func(t *testing.T) {
+		errortest.AssertError(t, VerifyDirectoryAgainstManifest(context.Background(), out, filepath.Join(out, faker.Word())), commonerrors.ErrNotFound)
+	})
+	t.Run("modified artefacts", func(t *testing.T) {
+		modified := t.TempDir()
+		require.NoError(t, filesystem.CopyToDirectoryWithContext(context.Background(), out, modified))
+		dir := filepath.Join(modified, filepath.Base(out))
+		first := filepath.Join(dir, manifest.Artefacts[0].RelativePath)
+		content, err := filesystem.ReadFile(first)
+		require.NoError(t, err)
+		content[0]++
+		require.NoError(t, filesystem.WriteFile(first, content, 0644))
+		err = VerifyDirectoryAgainstManifest(context.Background(), dir, manifestPath)
+		errortest.AssertError(t, err, commonerrors.ErrCondition)
+		assert.Contains(t, err.Error(), manifest.Artefacts[0].Name)
+
+		require.NoError(t, filesystem.Rm(filepath.Join(dir, manifest.Artefacts[1].RelativePath)))
+		err = VerifyDirectoryAgainstManifest(context.Background(), dir, manifestPath)
+		errortest.AssertError(t, err, commonerrors.ErrCondition)
+		errortest.AssertError(t, err, commonerrors.ErrNotFound)
+		assert.NotContains(t, err.Error(), manifest.Artefacts[2].Name)
+	})
+	t.Run("malicious manifest", func(t *testing.T) {
+		malicious := *manifest
+		malicious.Artefacts = []ManifestEntry{manifest.Artefacts[0]}
+		malicious.Artefacts[0].RelativePath = "../" + manifest.Artefacts[0].RelativePath
+		content, err := json.Marshal(malicious)
+		require.NoError(t, err)
+		maliciousPath := filepath.Join(t.TempDir(), DefaultManifestFileName)
+		require.NoError(t, filesystem.WriteFile(maliciousPath, content, 0644))
+		errortest.AssertError(t, VerifyDirectoryAgainstManifest(context.Background(), filepath.Join(out, faker.Word()), maliciousPath), commonerrors.ErrInvalid)
+	})
+}
+
+func TestChecksumLine(t *testing.T) {
+	hash := strings.ToUpper(faker.UUIDDigit())
+	assert.Equal(t, fmt.Sprintf("%v  test/file.txt\n", strings.ToLower(hash)), checksumLine(hash, "test/file.txt"))
+	assert.Equal(t, fmt.Sprintf("\\%v  test\\nfile\\\\.txt\n", strings.ToLower(hash)), checksumLine(hash, "test\nfile\\.txt"))
+}
+
+func TestManifestNameCollision(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefact := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	artefact.name = DefaultManifestFileName
+	m := newTestArtefactsManager(t, []*testArtefact{artefact}, false)
+
+	t.Run("renamed", func(t *testing.T) {
+		out := t.TempDir()
+		_, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithManifest(true), WithCollisionPolicy(CollisionPolicyRenameWithSuffix))
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(out, withFileNameSuffix(DefaultManifestFileName, "1")))
+		require.NoError(t, VerifyDirectoryAgainstManifest(context.Background(), out, filepath.Join(out, DefaultManifestFileName)))
+	})
+	t.Run("error", func(t *testing.T) {
+		out := t.TempDir()
+		_, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithManifest(true), WithCollisionPolicy(CollisionPolicyError))
+		errortest.AssertError(t, err, commonerrors.ErrExists)
+	})
+}
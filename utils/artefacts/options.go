@@ -5,13 +5,38 @@
 package artefacts
 
 import (
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/filesystem"
 	"github.com/ARM-software/golang-utils/utils/logs"
+	"github.com/ARM-software/golang-utils/utils/retry"
 )
 
 type DownloadOptions struct {
 	StopOnFirstError      bool
 	MaintainTreeStructure bool
 	Logger                logs.Loggers
+	Progress              IProgressReporter
+	CollisionPolicy       CollisionPolicy
+	GenerateManifest      bool
+	ArchiveFormat         ArchiveFormat
+	ArchivePath           string
+	Sink                  IArtefactSink
+	MinimumHashAlgorithm  string
+	RetryPolicy           *retry.RetryPolicyConfiguration
+	PollingPeriod         time.Duration
+	ExtractArchives       bool
+	DeleteArchives        bool
+	ExtractionLimits      filesystem.ILimits
+	PreserveAttributes    bool
+	ModificationTimeKey   string
+	FileModeKey           string
+	RateLimit             int64
+	Cache                 *ArtefactCache
+	LinkCachedArtefacts   bool
+	Concurrency           int
+	JobDirectoryTemplate  string
+	StopOnFirstJobError   bool
 }
 
 type DownloadOption func(*DownloadOptions)
@@ -21,6 +46,27 @@ func newDefaultDownloadOptions() *DownloadOptions {
 		StopOnFirstError:      true,
 		MaintainTreeStructure: false,
 		Logger:                nil,
+		Progress:              nil,
+		CollisionPolicy:       CollisionPolicyOverwrite,
+		GenerateManifest:      false,
+		ArchiveFormat:         ArchiveFormatNone,
+		ArchivePath:           "",
+		Sink:                  nil,
+		MinimumHashAlgorithm:  "",
+		RetryPolicy:           retry.DefaultNoRetryPolicyConfiguration(),
+		PollingPeriod:         DefaultFollowPollingPeriod,
+		ExtractArchives:       false,
+		DeleteArchives:        false,
+		ExtractionLimits:      filesystem.DefaultNonRecursiveZipLimits(),
+		PreserveAttributes:    false,
+		ModificationTimeKey:   DefaultModificationTimeMetadataKey,
+		FileModeKey:           DefaultFileModeMetadataKey,
+		RateLimit:             0,
+		Cache:                 nil,
+		LinkCachedArtefacts:   false,
+		Concurrency:           DefaultConcurrency,
+		JobDirectoryTemplate:  DefaultJobDirectoryTemplate,
+		StopOnFirstJobError:   false,
 	}
 }
 func NewDownloadOptions(opts ...DownloadOption) (options *DownloadOptions) {
@@ -51,3 +97,148 @@ func WithLogger(l logs.Loggers) DownloadOption {
 		o.Logger = l
 	}
 }
+
+// WithProgress specifies an optional reporter which is notified of the progress made while downloading artefacts.
+func WithProgress(reporter IProgressReporter) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.Progress = reporter
+	}
+}
+
+// WithCollisionPolicy specifies what happens when several artefacts of a same download are meant to be stored at the same location e.g. artefacts with the same title when the tree structure is not maintained.
+func WithCollisionPolicy(policy CollisionPolicy) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.CollisionPolicy = policy
+	}
+}
+
+// WithManifest specifies whether a manifest of the downloaded artefacts should be written in the output directory (see DefaultManifestFileName and DefaultChecksumFileName).
+// Artefacts which would be stored at the location of the manifest are renamed if the collision policy allows it or cannot be downloaded otherwise (see WithCollisionPolicy).
+func WithManifest(generate bool) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.GenerateManifest = generate
+	}
+}
+
+// WithArchive specifies that artefacts should be stored into a single archive of the given format rather than as individual files.
+// Entries follow the same layout as files would in the output directory and a manifest of the artefacts (see WithManifest) is added alongside them.
+// If archivePath is empty, the archive is created in the output directory and named after the job.
+func WithArchive(format ArchiveFormat, archivePath string) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.ArchiveFormat = format
+		o.ArchivePath = archivePath
+	}
+}
+
+// WithSink specifies where artefacts should be stored instead of the output directory e.g. an in-memory filesystem or a store (see NewFilesystemSink, NewStoreSink, NewWriterSink).
+// The sink takes precedence over WithArchive and is not closed once the download completes.
+func WithSink(sink IArtefactSink) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.Sink = sink
+	}
+}
+
+// WithMinimumHashAlgorithm specifies the weakest hashing algorithm accepted for verifying artefacts e.g. hashing.HashSha256 (see SupportedHashAlgorithms).
+// Artefacts whose hash was computed with a weaker algorithm are rejected.
+func WithMinimumHashAlgorithm(algorithm string) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.MinimumHashAlgorithm = algorithm
+	}
+}
+
+// WithRetryPolicy specifies how an artefact should be downloaded again if its transfer failed for a reason which may be transient e.g. an unavailable service, a connection reset or content not matching the expected size or hash.
+// Each artefact is attempted at most policy.RetryMax times and backoff is performed between attempts as configured (see retry.DefaultExponentialBackoffRetryPolicyConfiguration).
+// This is in addition to any retry performed by the HTTP client for individual requests.
+func WithRetryPolicy(policy *retry.RetryPolicyConfiguration) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.RetryPolicy = policy
+	}
+}
+
+// WithPollingPeriod specifies how often the artefacts of a running job are checked for changes when following a job (see FollowJobArtefacts).
+func WithPollingPeriod(period time.Duration) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.PollingPeriod = period
+	}
+}
+
+// WithExtractArchives specifies whether artefacts which are zip archives or gzip-compressed tarballs should be extracted into a directory named after them once verified e.g. `output.zip` is extracted into `output/`.
+// Entries which would be extracted outside this directory or at a location used by another artefact are rejected and only regular files are extracted. Entries of an archive which cannot be fully extracted are removed, unless artefacts are streamed (see NewWriterSink). If deleteArchive is set, the archive itself is not kept.
+func WithExtractArchives(extract bool, deleteArchive bool) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.ExtractArchives = extract
+		o.DeleteArchives = deleteArchive
+	}
+}
+
+// WithExtractionLimits specifies the limits on the number and size of files extracted from an archive (see WithExtractArchives) so that decompression bombs are detected.
+// By default, filesystem.DefaultNonRecursiveZipLimits apply.
+func WithExtractionLimits(limits filesystem.ILimits) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.ExtractionLimits = limits
+	}
+}
+
+// WithPreserveAttributes specifies whether the modification time and permissions stated in the artefacts' extra metadata should be applied to the downloaded files (see WithAttributeMetadataKeys).
+// Attributes are only applied if the sink supports them i.e. for files and archives. As they originate from the service, they are not applied by default.
+func WithPreserveAttributes(preserve bool) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.PreserveAttributes = preserve
+	}
+}
+
+// WithAttributeMetadataKeys specifies the keys of the artefacts' extra metadata holding their modification time and permissions, if different from DefaultModificationTimeMetadataKey and DefaultFileModeMetadataKey.
+// An empty key means the corresponding attribute is not applied.
+func WithAttributeMetadataKeys(modificationTimeKey, fileModeKey string) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.ModificationTimeKey = modificationTimeKey
+		o.FileModeKey = fileModeKey
+	}
+}
+
+// WithRateLimit limits the bandwidth used for downloading artefacts to bytesPerSecond. The limit is shared by all the artefacts downloaded by a same call, even concurrently e.g. the artefacts of all the jobs downloaded using DownloadArtefactsForJobs.
+// No limit applies if bytesPerSecond is not positive.
+func WithRateLimit(bytesPerSecond int64) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.RateLimit = bytesPerSecond
+	}
+}
+
+// WithCache specifies a cache of artefacts to look up before fetching any artefact and to add fetched artefacts to. Cached artefacts are copied from the cache entries unless stated otherwise (see WithCacheHardLinks).
+func WithCache(cache *ArtefactCache) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.Cache = cache
+	}
+}
+
+// WithCacheHardLinks specifies whether cached artefacts should be stored as hard links to the cache entries when possible rather than copied, to save disk space and time.
+// As hard links share their content with the cache entries and with any other artefact linked to them, artefacts downloaded this way must not be modified in place.
+func WithCacheHardLinks(link bool) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.LinkCachedArtefacts = link
+	}
+}
+
+// WithConcurrency specifies the maximum number of artefacts downloaded at the same time when downloading the artefacts of several jobs (see DownloadArtefactsForJobs).
+// As the artefacts of a job are downloaded one after the other, this is also the maximum number of jobs processed at the same time. Jobs are processed one at a time if concurrency is not positive.
+func WithConcurrency(concurrency int) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// WithJobDirectoryTemplate specifies how the directory holding the artefacts of each job is named when downloading the artefacts of several jobs (see DownloadArtefactsForJobs and JobDirectoryTemplateData).
+// The template follows the text/template syntax e.g. `{{.Index}}-{{.Job}}` and must result in a distinct path, relative to the output directory, for each job.
+func WithJobDirectoryTemplate(template string) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.JobDirectoryTemplate = template
+	}
+}
+
+// WithStopOnFirstJobError specifies whether the download of the artefacts of all jobs should stop as soon as the download of the artefacts of one job fails when downloading the artefacts of several jobs (see DownloadArtefactsForJobs).
+// By default, a failure for one job does not prevent the artefacts of other jobs from being downloaded. How failures are handled within a job is specified by WithStopOnFirstError.
+func WithStopOnFirstJobError(stop bool) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.StopOnFirstJobError = stop
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+const reservedNamePrefix = "_"
+
+// normaliseSeparators converts any Windows-style separator into a forward slash so that paths provided by the service are interpreted the same way on all platforms.
+func normaliseSeparators(p string) string {
+	return strings.ReplaceAll(p, "\\", "/")
+}
+
+func hasVolumeName(p string) bool {
+	if filepath.VolumeName(p) != "" {
+		return true
+	}
+	// Drive letters e.g. `C:` are considered on all platforms as service paths are platform-agnostic.
+	return len(p) >= 2 && p[1] == ':' && (('a' <= p[0] && p[0] <= 'z') || ('A' <= p[0] && p[0] <= 'Z'))
+}
+
+// isWindowsReservedName states whether a name is reserved on Windows (e.g. `CON`, `NUL.txt`, `COM1`) and hence, cannot be used as a file name.
+func isWindowsReservedName(name string) bool {
+	stem, _, _ := strings.Cut(name, ".")
+	stem = strings.ToUpper(strings.TrimSpace(stem))
+	switch stem {
+	case "CON", "PRN", "AUX", "NUL":
+		return true
+	}
+	return len(stem) == 4 && (strings.HasPrefix(stem, "COM") || strings.HasPrefix(stem, "LPT")) && stem[3] >= '1' && stem[3] <= '9'
+}
+
+// sanitisePathComponent checks that a single path element is safe to use and renames it if it is reserved.
+func sanitisePathComponent(component string) (sanitised string, err error) {
+	if strings.ContainsRune(component, 0) {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "path element [%v] contains a null character", component)
+		return
+	}
+	// On Windows, trailing dots and spaces are dropped and so, elements such as `. .` are equivalent to `..`
+	if strings.Trim(component, ". ") == "" {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "path element [%v] refers to a current or parent directory", component)
+		return
+	}
+	sanitised = component
+	if isWindowsReservedName(component) {
+		sanitised = reservedNamePrefix + component
+	}
+	return
+}
+
+// sanitiseFileName ensures that an artefact file name cannot be used to write outside its destination directory.
+// Names containing path separators e.g. `reports/out.log` are reduced to their last element as the location of artefacts is determined by their relative path. Absolute paths, volumes and parent directory references are rejected.
+func sanitiseFileName(name string) (sanitised string, err error) {
+	normalised := normaliseSeparators(name)
+	if strings.HasPrefix(normalised, "/") || hasVolumeName(normalised) {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "artefact file name [%v] must not be absolute or contain any volume", name)
+		return
+	}
+	for _, component := range strings.Split(normalised, "/") {
+		// as in sanitisePathComponent, elements such as `. .` are considered equivalent to `..`
+		if component != "" && component != "." && strings.Trim(component, ". ") == "" {
+			err = commonerrors.Newf(commonerrors.ErrInvalid, "artefact file name [%v] must not refer to a parent directory", name)
+			return
+		}
+	}
+	sanitised, err = sanitisePathComponent(path.Base(normalised))
+	return
+}
+
+// sanitiseRelativePath ensures that a path relative to an output directory cannot escape it.
+// Absolute paths and paths going above the output directory are rejected.
+func sanitiseRelativePath(relativePath string) (sanitised string, err error) {
+	normalised := normaliseSeparators(relativePath)
+	if normalised == "" {
+		return
+	}
+	if strings.HasPrefix(normalised, "/") || hasVolumeName(normalised) {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "artefact relative path [%v] must not be absolute", relativePath)
+		return
+	}
+	var elements []string
+	for _, component := range strings.Split(path.Clean(normalised), "/") {
+		if component == "." || component == "" {
+			continue
+		}
+		if component == ".." {
+			err = commonerrors.Newf(commonerrors.ErrInvalid, "artefact relative path [%v] points outside the output directory", relativePath)
+			return
+		}
+		element, subErr := sanitisePathComponent(component)
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, subErr, "invalid artefact relative path [%v]", relativePath)
+			return
+		}
+		elements = append(elements, element)
+	}
+	sanitised = filepath.Join(elements...)
+	return
+}
+
+// checkDestinationIsWithinDirectory verifies that writing to destination will not result in writing outside root by following symbolic links.
+// The deepest existing element of destination is resolved and must be located in root once root is resolved itself.
+func checkDestinationIsWithinDirectory(root, destination string) (err error) {
+	fs := filesystem.GetGlobalFileSystem()
+	resolvedRoot, err := filesystem.EvalSymlinks(fs, root)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not resolve output directory [%v]", root)
+		return
+	}
+	if !transfer.IsWithinDirectory(root, destination) {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "destination [%v] is outside the output directory [%v]", destination, root)
+		return
+	}
+	existing := filepath.Clean(destination)
+	for {
+		if _, statErr := filesystem.Lstat(existing); statErr == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing || !transfer.IsWithinDirectory(root, parent) {
+			return
+		}
+		existing = parent
+	}
+	resolved, err := filesystem.EvalSymlinks(fs, existing)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "could not resolve destination [%v]: it may be a dangling symbolic link", existing)
+		return
+	}
+	if !transfer.IsWithinDirectory(resolvedRoot, resolved) {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "destination [%v] resolves to [%v] which is outside the output directory [%v]", destination, resolved, root)
+	}
+	return
+}
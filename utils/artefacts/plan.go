@@ -0,0 +1,224 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+	"github.com/ARM-software/golang-utils/utils/reflection"
+	"github.com/ARM-software/golang-utils/utils/safecast"
+)
+
+// PlannedArtefact describes where an artefact would be stored if it was downloaded.
+type PlannedArtefact struct {
+	// Name is the name of the artefact.
+	Name string
+	// Destination is the path where the artefact would be stored. If artefacts are downloaded into an archive, it is the path of the entry in the archive.
+	Destination string
+	// RelativePath is the slash-separated path of the artefact relative to the root of the destination e.g. the output directory.
+	RelativePath string
+	// ExtractedTo is the path of the directory the artefact would be extracted to if it is an archive and extraction is requested (see WithExtractArchives).
+	ExtractedTo string
+	// OriginalDestination is the path where the artefact would be stored if it did not have to be renamed to avoid a collision. It is empty if the artefact would not be renamed.
+	OriginalDestination string
+	// Size is the expected size in bytes of the artefact.
+	Size int64
+	// Hash is the expected hexadecimal digest of the artefact content.
+	Hash string
+	// HashAlgorithm is the algorithm the artefact content would be verified with.
+	HashAlgorithm string
+	// Err is the reason why the artefact could not be downloaded, if any.
+	Err error
+}
+
+// DownloadPlan describes what a download of a job's artefacts would fetch and where, without fetching any content.
+type DownloadPlan struct {
+	// Job is the name of the job.
+	Job string
+	// Artefacts lists the artefacts which would be processed, in order.
+	Artefacts []PlannedArtefact
+	// TotalSize is the total number of bytes which would be downloaded.
+	TotalSize int64
+	// FreeDiskSpace is the number of bytes available where artefacts would be stored. It is 0 if it could not be determined e.g. if a custom sink is used (see WithSink).
+	FreeDiskSpace uint64
+	// InsufficientDiskSpace states whether the artefacts are not expected to fit in the free disk space.
+	InsufficientDiskSpace bool
+}
+
+// HasFailures states whether some artefacts could not be downloaded.
+func (p *DownloadPlan) HasFailures() bool {
+	if p == nil {
+		return false
+	}
+	for i := range p.Artefacts {
+		if p.Artefacts[i].Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// determineFreeDiskSpace returns the number of bytes available on the disk holding directory.
+var determineFreeDiskSpace = func(directory string) (free uint64, err error) {
+	usage, err := filesystem.GetGlobalFileSystem().DiskUsage(directory)
+	if err != nil {
+		return
+	}
+	free = usage.GetFree()
+	return
+}
+
+// planJobArtefact checks that an artefact can be downloaded and claims the location it should be stored at according to the collision policy.
+func planJobArtefact[M IManager](session *downloadSession, artefactManager M) (planned PlannedArtefact, expectedHash transfer.Hash, err error) {
+	if any(artefactManager) == nil {
+		err = commonerrors.UndefinedVariable("artefact manager")
+		return
+	}
+
+	planned.Name = artefactManager.GetName()
+	if planned.Name == "" {
+		err = commonerrors.UndefinedVariable("artefact name")
+		return
+	}
+
+	expectedSizePtr, ok := artefactManager.GetSizeOk()
+	if !ok {
+		err = commonerrors.Newf(commonerrors.ErrUndefined, "could not fetch artefact's size from artefact's manager [%v]", planned.Name)
+		return
+	}
+	planned.Size = *expectedSizePtr
+
+	expectedHashPtr, ok := artefactManager.GetHashOk()
+	if !ok {
+		err = commonerrors.Newf(commonerrors.ErrUndefined, "could not fetch artefact's hash from artefact's manager [%v]", planned.Name)
+		return
+	}
+	expectedHash, err = transfer.ParseHash(*expectedHashPtr)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "invalid hash for artefact [%v]", planned.Name)
+		return
+	}
+	planned.Hash = expectedHash.Digest
+	planned.HashAlgorithm = expectedHash.Algorithm
+	err = checkMinimumHashAlgorithm(expectedHash.Algorithm, session.options.MinimumHashAlgorithm)
+	if err != nil {
+		return
+	}
+	plannedPath, err := determineArtefactRelativePath(session.options.MaintainTreeStructure, artefactManager)
+	if err != nil {
+		return
+	}
+	planned.RelativePath, err = session.destinations.claim(plannedPath, planned.Name, expectedHash.Digest)
+	if err != nil {
+		return
+	}
+	planned.Destination = session.sink.Location(planned.RelativePath)
+	if planned.RelativePath != plannedPath {
+		planned.OriginalDestination = session.sink.Location(plannedPath)
+	}
+	if session.options.ExtractArchives {
+		if format, extractionDirectory := determineExtractionDirectory(planned.RelativePath); format != ArchiveFormatNone {
+			planned.ExtractedTo = session.sink.Location(extractionDirectory)
+		}
+	}
+	return
+}
+
+// PlanJobArtefactsDownload determines what DownloadAllJobArtefactsWithOptions would download and where given the same options, without downloading any content or creating anything.
+// Artefacts which could not be downloaded are listed along with the reason. If the artefacts are not expected to fit in the free disk space, an error is logged but planning does not fail.
+func (m *ArtefactManager[M, D, L, C]) PlanJobArtefactsDownload(ctx context.Context, jobName string, outputDirectory string, opts ...DownloadOption) (plan *DownloadPlan, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	session := newDownloadSession(jobName, NewDownloadOptions(opts...))
+	plan = &DownloadPlan{Job: jobName}
+	storageDirectory := session.planSink(outputDirectory)
+
+	var collatedErrors []error
+	for artefact, subErr := range m.iterateJobArtefacts(ctx, jobName) {
+		if artefact == nil {
+			err = subErr
+			return
+		}
+		planned := PlannedArtefact{Name: artefact.name, Err: subErr}
+		if subErr == nil {
+			var planErr error
+			planned, _, planErr = planJobArtefact(session, artefact.manager)
+			planned.Err = planErr
+			if planned.Name == "" {
+				planned.Name = artefact.name
+			}
+		}
+		plan.Artefacts = append(plan.Artefacts, planned)
+		if planned.Err != nil {
+			if session.options.StopOnFirstError {
+				err = planned.Err
+				return
+			}
+			collatedErrors = append(collatedErrors, planned.Err)
+			continue
+		}
+		plan.TotalSize += planned.Size
+	}
+
+	if !reflection.IsEmpty(storageDirectory) {
+		plan.checkDiskSpace(storageDirectory, session.options)
+	}
+	if len(collatedErrors) > 0 {
+		err = commonerrors.Join(collatedErrors...)
+	}
+	return
+}
+
+// planSink sets the sink the artefacts would be stored in without creating anything and returns the local directory artefacts would be written to, if any.
+func (s *downloadSession) planSink(outputDirectory string) (storageDirectory string) {
+	if s.options.Sink != nil {
+		s.sink = s.options.Sink
+		return
+	}
+	if s.options.ArchiveFormat == ArchiveFormatNone {
+		s.sink = NewDirectorySink(outputDirectory)
+		storageDirectory = outputDirectory
+		return
+	}
+	s.sink = &archiveSink{}
+	storageDirectory = outputDirectory
+	if !reflection.IsEmpty(s.options.ArchivePath) {
+		storageDirectory = filepath.Dir(s.options.ArchivePath)
+	}
+	return
+}
+
+// checkDiskSpace determines the free disk space where artefacts would be written. As directory may not exist yet, the closest existing parent directory is considered.
+func (p *DownloadPlan) checkDiskSpace(directory string, options *DownloadOptions) {
+	directory = filepath.Clean(directory)
+	for !filesystem.Exists(directory) {
+		parent := filepath.Dir(directory)
+		if parent == directory {
+			return
+		}
+		directory = parent
+	}
+	free, err := determineFreeDiskSpace(directory)
+	if err != nil {
+		if options.Logger != nil {
+			options.Logger.LogError(fmt.Sprintf("could not determine the free disk space in [%v]: %v", directory, err))
+		}
+		return
+	}
+	p.FreeDiskSpace = free
+	p.InsufficientDiskSpace = safecast.ToUint64(p.TotalSize) > free
+	if p.InsufficientDiskSpace && options.Logger != nil {
+		options.Logger.LogError(fmt.Sprintf("artefacts of job [%v] require %v bytes but only %v bytes are available in [%v]", p.Job, p.TotalSize, free, directory))
+	}
+}
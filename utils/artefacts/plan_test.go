@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/logs"
+)
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	size, err := filesystem.GetFileSize(path)
+	require.NoError(t, err)
+	return size
+}
+
+// errorCountingLogger counts the errors logged.
+type errorCountingLogger struct {
+	*logs.StringLoggers
+	errors int
+}
+
+func (l *errorCountingLogger) LogError(err ...interface{}) {
+	l.errors++
+	l.StringLoggers.LogError(err...)
+}
+
+func TestPlanJobArtefactsDownload(t *testing.T) {
+	tmpDir := t.TempDir()
+	first := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	first.title = "output.txt"
+	second := newTestArtefact(t, tmpDir, faker.Paragraph(), true, false)
+	second.title = "output.txt"
+	archive := newTestArchiveArtefact(t, tmpDir, "logs.zip", newTestZip(t, map[string]string{"run.log": faker.Paragraph()}))
+	artefacts := []*testArtefact{first, second, archive}
+	expectedSize := fileSize(t, first.path) + fileSize(t, second.path) + fileSize(t, archive.path)
+
+	t.Run("directory", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "out")
+		plan, err := newTestArtefactsManager(t, artefacts, false).PlanJobArtefactsDownload(context.Background(), faker.Word(), out, WithCollisionPolicy(CollisionPolicyRenameWithSuffix), WithExtractArchives(true, false))
+		require.NoError(t, err)
+		require.Len(t, plan.Artefacts, len(artefacts))
+		assert.False(t, plan.HasFailures())
+		assert.Equal(t, expectedSize, plan.TotalSize)
+		assert.Equal(t, filepath.Join(out, "output.txt"), plan.Artefacts[0].Destination)
+		assert.Empty(t, plan.Artefacts[0].OriginalDestination)
+		assert.Equal(t, "output_1.txt", plan.Artefacts[1].RelativePath)
+		assert.Equal(t, filepath.Join(out, "output_1.txt"), plan.Artefacts[1].Destination)
+		assert.Equal(t, filepath.Join(out, "output.txt"), plan.Artefacts[1].OriginalDestination)
+		assert.Equal(t, filepath.Join(out, "logs"), plan.Artefacts[2].ExtractedTo)
+		assert.Empty(t, plan.Artefacts[0].ExtractedTo)
+		assert.NotZero(t, plan.FreeDiskSpace)
+		assert.False(t, plan.InsufficientDiskSpace)
+		assert.NoDirExists(t, out)
+	})
+	t.Run("archive", func(t *testing.T) {
+		out := t.TempDir()
+		plan, err := newTestArtefactsManager(t, artefacts, false).PlanJobArtefactsDownload(context.Background(), faker.Word(), out, WithArchive(ArchiveFormatZip, filepath.Join(out, "artefacts.zip")))
+		require.NoError(t, err)
+		require.Len(t, plan.Artefacts, len(artefacts))
+		assert.Equal(t, "output.txt", plan.Artefacts[1].Destination)
+		assert.NoFileExists(t, filepath.Join(out, "artefacts.zip"))
+	})
+	t.Run("collision error", func(t *testing.T) {
+		plan, err := newTestArtefactsManager(t, artefacts, false).PlanJobArtefactsDownload(context.Background(), faker.Word(), t.TempDir(), WithCollisionPolicy(CollisionPolicyError), WithStopOnFirstError(false))
+		errortest.AssertError(t, err, commonerrors.ErrExists)
+		require.NotNil(t, plan)
+		require.Len(t, plan.Artefacts, len(artefacts))
+		assert.True(t, plan.HasFailures())
+		errortest.AssertError(t, plan.Artefacts[1].Err, commonerrors.ErrExists)
+		assert.Equal(t, expectedSize-fileSize(t, second.path), plan.TotalSize)
+
+		plan, err = newTestArtefactsManager(t, artefacts, false).PlanJobArtefactsDownload(context.Background(), faker.Word(), t.TempDir(), WithCollisionPolicy(CollisionPolicyError))
+		errortest.AssertError(t, err, commonerrors.ErrExists)
+		assert.Len(t, plan.Artefacts, 2)
+	})
+	t.Run("insufficient disk space", func(t *testing.T) {
+		determine := determineFreeDiskSpace
+		defer func() { determineFreeDiskSpace = determine }()
+		determineFreeDiskSpace = func(string) (uint64, error) { return 10, nil }
+		stringLogger, err := logs.NewStringLogger(faker.Word())
+		require.NoError(t, err)
+		logger := &errorCountingLogger{StringLoggers: stringLogger}
+		plan, err := newTestArtefactsManager(t, artefacts, false).PlanJobArtefactsDownload(context.Background(), faker.Word(), t.TempDir(), WithLogger(logger))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), plan.FreeDiskSpace)
+		assert.True(t, plan.InsufficientDiskSpace)
+		assert.Contains(t, logger.GetLogContent(), "bytes are available")
+		assert.Equal(t, 1, logger.errors)
+	})
+	t.Run("custom sink", func(t *testing.T) {
+		plan, err := newTestArtefactsManager(t, artefacts, false).PlanJobArtefactsDownload(context.Background(), faker.Word(), "", WithSink(NewWriterSink(func(context.Context, string) (io.Writer, error) { return io.Discard, nil })))
+		require.NoError(t, err)
+		assert.Zero(t, plan.FreeDiskSpace)
+		assert.False(t, plan.InsufficientDiskSpace)
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := newTestArtefactsManager(t, artefacts, false).PlanJobArtefactsDownload(ctx, faker.Word(), t.TempDir())
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+	})
+}
@@ -0,0 +1,280 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/logs"
+	"github.com/ARM-software/golang-utils/utils/units/size"
+)
+
+const (
+	// DefaultProgressReportingPeriod describes the default period at which progress summaries are reported.
+	DefaultProgressReportingPeriod = 5 * time.Second
+	unknownSize                    = int64(-1)
+	progressBarWidth               = 30
+	progressBarRefreshPeriod       = 100 * time.Millisecond
+)
+
+// ArtefactProgress describes the progress made when downloading a particular artefact.
+type ArtefactProgress struct {
+	// Name is the name of the artefact.
+	Name string
+	// Transferred is the number of bytes transferred so far.
+	Transferred int64
+	// ExpectedSize is the size stated by the artefact manager (see IManager.GetSizeOk). It is negative if unknown.
+	ExpectedSize int64
+}
+
+// Percentage returns the percentage of the artefact transferred so far or a negative number if it cannot be determined.
+func (p *ArtefactProgress) Percentage() float64 {
+	return percentage(p.Transferred, p.ExpectedSize)
+}
+
+// JobProgress describes the overall progress made when downloading the artefacts of a job.
+type JobProgress struct {
+	// Job is the name of the job.
+	Job string
+	// Started is the number of artefact downloads which have started.
+	Started int
+	// Completed is the number of artefacts successfully downloaded.
+	Completed int
+	// Failed is the number of artefacts which could not be downloaded.
+	Failed int
+	// Transferred is the total number of bytes transferred so far.
+	Transferred int64
+	// ExpectedSize is the sum of the expected sizes of all the artefacts started so far.
+	ExpectedSize int64
+}
+
+// InProgress returns the number of artefacts currently being downloaded.
+func (p *JobProgress) InProgress() int {
+	return p.Started - p.Completed - p.Failed
+}
+
+// IProgressReporter defines a reporter of artefact download progress.
+// Calls are serialised by the artefact manager and so, implementations do not need to be thread-safe.
+type IProgressReporter interface {
+	// OnStart is called when the download of an artefact starts.
+	OnStart(artefact ArtefactProgress, job JobProgress)
+	// OnProgress is called every time some bytes of an artefact are transferred.
+	OnProgress(artefact ArtefactProgress, job JobProgress)
+	// OnFinish is called when an artefact has been successfully downloaded.
+	OnFinish(artefact ArtefactProgress, job JobProgress)
+	// OnError is called when an artefact could not be downloaded.
+	OnError(artefact ArtefactProgress, job JobProgress, err error)
+}
+
+// progressTracker keeps track of the progress of all the artefact downloads of a job and notifies a reporter.
+type progressTracker struct {
+	mu       sync.Mutex
+	reporter IProgressReporter
+	job      JobProgress
+}
+
+func newProgressTracker(jobName string, reporter IProgressReporter) *progressTracker {
+	return &progressTracker{
+		reporter: reporter,
+		job:      JobProgress{Job: jobName},
+	}
+}
+
+func (t *progressTracker) newArtefactTracker(name string) *artefactProgressTracker {
+	return &artefactProgressTracker{
+		parent:   t,
+		progress: ArtefactProgress{Name: name, ExpectedSize: unknownSize},
+	}
+}
+
+func (t *progressTracker) isEnabled() bool {
+	return t != nil && t.reporter != nil
+}
+
+// artefactProgressTracker keeps track of the progress of a single artefact download.
+// It is an io.Writer so that it can be fed with the bytes being transferred.
+type artefactProgressTracker struct {
+	parent   *progressTracker
+	progress ArtefactProgress
+	started  bool
+}
+
+// start reports the start of the artefact download. If the download was already started, i.e. the artefact is downloaded again, the bytes transferred so far are discarded.
+func (a *artefactProgressTracker) start(expectedSize int64) {
+	if !a.parent.isEnabled() {
+		return
+	}
+	a.parent.mu.Lock()
+	defer a.parent.mu.Unlock()
+	if a.started {
+		a.parent.job.Transferred -= a.progress.Transferred
+		a.progress.Transferred = 0
+		a.parent.reporter.OnStart(a.progress, a.parent.job)
+		return
+	}
+	a.started = true
+	a.progress.ExpectedSize = expectedSize
+	a.parent.job.Started++
+	if expectedSize > 0 {
+		a.parent.job.ExpectedSize += expectedSize
+	}
+	a.parent.reporter.OnStart(a.progress, a.parent.job)
+}
+
+func (a *artefactProgressTracker) Write(p []byte) (n int, err error) {
+	n = len(p)
+	if !a.parent.isEnabled() || n == 0 {
+		return
+	}
+	a.parent.mu.Lock()
+	defer a.parent.mu.Unlock()
+	a.progress.Transferred += int64(n)
+	a.parent.job.Transferred += int64(n)
+	a.parent.reporter.OnProgress(a.progress, a.parent.job)
+	return
+}
+
+// done reports the outcome of the artefact download.
+func (a *artefactProgressTracker) done(err error) {
+	if !a.parent.isEnabled() {
+		return
+	}
+	a.parent.mu.Lock()
+	defer a.parent.mu.Unlock()
+	if !a.started {
+		a.started = true
+		a.parent.job.Started++
+	}
+	if err == nil {
+		a.parent.job.Completed++
+		a.parent.reporter.OnFinish(a.progress, a.parent.job)
+	} else {
+		a.parent.job.Failed++
+		a.parent.reporter.OnError(a.progress, a.parent.job, err)
+	}
+}
+
+type terminalProgressBar struct {
+	writer      io.Writer
+	lastRefresh time.Time
+	lastLength  int
+}
+
+func (b *terminalProgressBar) OnStart(artefact ArtefactProgress, job JobProgress) {
+	b.draw(artefact, job, true)
+}
+
+func (b *terminalProgressBar) OnProgress(artefact ArtefactProgress, job JobProgress) {
+	b.draw(artefact, job, false)
+}
+
+func (b *terminalProgressBar) OnFinish(artefact ArtefactProgress, job JobProgress) {
+	b.draw(artefact, job, true)
+	_, _ = fmt.Fprintln(b.writer)
+	b.lastLength = 0
+}
+
+func (b *terminalProgressBar) OnError(artefact ArtefactProgress, job JobProgress, err error) {
+	b.draw(artefact, job, true)
+	_, _ = fmt.Fprintf(b.writer, "\nfailed downloading artefact [%v]: %v\n", artefact.Name, err)
+	b.lastLength = 0
+}
+
+func (b *terminalProgressBar) draw(artefact ArtefactProgress, job JobProgress, force bool) {
+	if !force && time.Since(b.lastRefresh) < progressBarRefreshPeriod {
+		return
+	}
+	b.lastRefresh = time.Now()
+	line := fmt.Sprintf("%v %v %v/%v [job: %v/%v artefacts, %v]", renderBar(artefact.Percentage()), artefact.Name, formatSize(artefact.Transferred), formatSize(artefact.ExpectedSize), job.Completed, job.Started, formatSize(job.Transferred))
+	padding := ""
+	if len(line) < b.lastLength {
+		padding = strings.Repeat(" ", b.lastLength-len(line))
+	}
+	b.lastLength = len(line)
+	_, _ = fmt.Fprintf(b.writer, "\r%v%v", line, padding)
+}
+
+// NewTerminalProgressBar returns a progress reporter which draws a progress bar for each artefact being downloaded on a terminal.
+func NewTerminalProgressBar(w io.Writer) IProgressReporter {
+	return &terminalProgressBar{writer: w}
+}
+
+type loggingProgressReporter struct {
+	logger  logs.Loggers
+	period  time.Duration
+	lastLog time.Time
+}
+
+func (r *loggingProgressReporter) OnStart(artefact ArtefactProgress, _ JobProgress) {
+	r.logger.Log(fmt.Sprintf("starting download of artefact [%v] (%v)", artefact.Name, formatSize(artefact.ExpectedSize)))
+}
+
+func (r *loggingProgressReporter) OnProgress(artefact ArtefactProgress, job JobProgress) {
+	if time.Since(r.lastLog) < r.period {
+		return
+	}
+	r.lastLog = time.Now()
+	r.logger.Log(fmt.Sprintf("artefact [%v]: %v/%v%v; job [%v]: %v downloaded, %v in progress, %v failed, %v/%v transferred", artefact.Name, formatSize(artefact.Transferred), formatSize(artefact.ExpectedSize), formatPercentage(artefact.Percentage()), job.Job, job.Completed, job.InProgress(), job.Failed, formatSize(job.Transferred), formatSize(job.ExpectedSize)))
+}
+
+func (r *loggingProgressReporter) OnFinish(artefact ArtefactProgress, job JobProgress) {
+	r.logger.Log(fmt.Sprintf("artefact [%v] downloaded (%v); job [%v]: %v downloaded, %v failed", artefact.Name, formatSize(artefact.Transferred), job.Job, job.Completed, job.Failed))
+}
+
+func (r *loggingProgressReporter) OnError(artefact ArtefactProgress, _ JobProgress, err error) {
+	r.logger.LogError(fmt.Sprintf("artefact [%v] could not be downloaded: %v", artefact.Name, err))
+}
+
+// NewLoggingProgressReporter returns a progress reporter which logs summaries of the download progress at regular intervals.
+// If period is not positive, DefaultProgressReportingPeriod is used.
+func NewLoggingProgressReporter(logger logs.Loggers, period time.Duration) IProgressReporter {
+	if period <= 0 {
+		period = DefaultProgressReportingPeriod
+	}
+	return &loggingProgressReporter{
+		logger:  logger,
+		period:  period,
+		lastLog: time.Now(),
+	}
+}
+
+func percentage(transferred, expected int64) float64 {
+	if expected <= 0 {
+		return -1
+	}
+	return 100 * float64(transferred) / float64(expected)
+}
+
+func formatPercentage(p float64) string {
+	if p < 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%.0f%%)", p)
+}
+
+func renderBar(p float64) string {
+	if p < 0 {
+		return fmt.Sprintf("[%v]    ?", strings.Repeat("?", progressBarWidth))
+	}
+	filled := int(p * progressBarWidth / 100)
+	filled = min(max(filled, 0), progressBarWidth)
+	return fmt.Sprintf("[%v%v] %3.0f%%", strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled), p)
+}
+
+func formatSize(bytes int64) string {
+	if bytes < 0 {
+		return "unknown size"
+	}
+	s, err := size.FormatSizeAsBinarySI(float64(bytes), 1)
+	if err != nil {
+		return fmt.Sprintf("%vB", bytes)
+	}
+	return s
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/logs"
+)
+
+type testProgressEvent struct {
+	event    string
+	artefact ArtefactProgress
+	job      JobProgress
+}
+
+type testProgressReporter struct {
+	events []testProgressEvent
+}
+
+func (r *testProgressReporter) OnStart(artefact ArtefactProgress, job JobProgress) {
+	r.events = append(r.events, testProgressEvent{event: "start", artefact: artefact, job: job})
+}
+
+func (r *testProgressReporter) OnProgress(artefact ArtefactProgress, job JobProgress) {
+	r.events = append(r.events, testProgressEvent{event: "progress", artefact: artefact, job: job})
+}
+
+func (r *testProgressReporter) OnFinish(artefact ArtefactProgress, job JobProgress) {
+	r.events = append(r.events, testProgressEvent{event: "finish", artefact: artefact, job: job})
+}
+
+func (r *testProgressReporter) OnError(artefact ArtefactProgress, job JobProgress, _ error) {
+	r.events = append(r.events, testProgressEvent{event: "error", artefact: artefact, job: job})
+}
+
+func (r *testProgressReporter) count(event string) (c int) {
+	for i := range r.events {
+		if r.events[i].event == event {
+			c++
+		}
+	}
+	return
+}
+
+func (r *testProgressReporter) last() testProgressEvent {
+	return r.events[len(r.events)-1]
+}
+
+func TestProgressTracker(t *testing.T) {
+	t.Run("no reporter", func(t *testing.T) {
+		tracker := newProgressTracker(faker.Word(), nil)
+		a := tracker.newArtefactTracker(faker.Word())
+		a.start(10)
+		n, err := a.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+		a.done(nil)
+	})
+	t.Run("with reporter", func(t *testing.T) {
+		reporter := &testProgressReporter{}
+		jobName := faker.Word()
+		tracker := newProgressTracker(jobName, reporter)
+		a := tracker.newArtefactTracker(faker.Word())
+		a.start(10)
+		_, err := a.Write([]byte("hello"))
+		require.NoError(t, err)
+		_, err = a.Write([]byte("world"))
+		require.NoError(t, err)
+		a.done(nil)
+		b := tracker.newArtefactTracker(faker.Word())
+		b.done(commonerrors.ErrUnexpected)
+
+		assert.Equal(t, 1, reporter.count("start"))
+		assert.Equal(t, 2, reporter.count("progress"))
+		assert.Equal(t, 1, reporter.count("finish"))
+		assert.Equal(t, 1, reporter.count("error"))
+		last := reporter.last()
+		assert.Equal(t, jobName, last.job.Job)
+		assert.Equal(t, 2, last.job.Started)
+		assert.Equal(t, 1, last.job.Completed)
+		assert.Equal(t, 1, last.job.Failed)
+		assert.Zero(t, last.job.InProgress())
+		assert.Equal(t, int64(10), last.job.Transferred)
+		assert.Equal(t, int64(10), last.job.ExpectedSize)
+		assert.Equal(t, unknownSize, last.artefact.ExpectedSize)
+		assert.Negative(t, last.artefact.Percentage())
+	})
+}
+
+func TestTerminalProgressBar(t *testing.T) {
+	var b bytes.Buffer
+	reporter := NewTerminalProgressBar(&b)
+	name := faker.Word()
+	job := JobProgress{Job: faker.Word(), Started: 1}
+	reporter.OnStart(ArtefactProgress{Name: name, ExpectedSize: 2048}, job)
+	reporter.OnProgress(ArtefactProgress{Name: name, Transferred: 1024, ExpectedSize: 2048}, job)
+	job.Completed = 1
+	reporter.OnFinish(ArtefactProgress{Name: name, Transferred: 2048, ExpectedSize: 2048}, job)
+	reporter.OnError(ArtefactProgress{Name: name, ExpectedSize: unknownSize}, job, commonerrors.ErrUnexpected)
+	output := b.String()
+	assert.Contains(t, output, name)
+	assert.Contains(t, output, "100%")
+	assert.Contains(t, output, "failed downloading artefact")
+}
+
+func TestLoggingProgressReporter(t *testing.T) {
+	logger, err := logs.NewStringLogger(faker.Word())
+	require.NoError(t, err)
+	reporter := NewLoggingProgressReporter(logger, time.Nanosecond)
+	name := faker.Word()
+	job := JobProgress{Job: faker.Word(), Started: 1}
+	reporter.OnStart(ArtefactProgress{Name: name, ExpectedSize: 2048}, job)
+	time.Sleep(time.Millisecond)
+	reporter.OnProgress(ArtefactProgress{Name: name, Transferred: 1024, ExpectedSize: 2048}, job)
+	job.Completed = 1
+	reporter.OnFinish(ArtefactProgress{Name: name, Transferred: 2048, ExpectedSize: 2048}, job)
+	reporter.OnError(ArtefactProgress{Name: name, ExpectedSize: unknownSize}, job, commonerrors.ErrUnexpected)
+	output := logger.GetLogContent()
+	assert.Contains(t, output, "starting download of artefact")
+	assert.Contains(t, output, "(50%)")
+	assert.Contains(t, output, "downloaded")
+	assert.Contains(t, output, "could not be downloaded")
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+)
+
+// rateLimiter is a token bucket limiting the number of bytes transferred per second. It can be shared by concurrent transfers.
+// Tokens can be borrowed so that a transfer only waits once the bucket is in debt.
+type rateLimiter struct {
+	mu             sync.Mutex
+	bytesPerSecond float64
+	burst          float64
+	tokens         float64
+	last           time.Time
+}
+
+// newRateLimiter returns a limiter allowing bytesPerSecond bytes per second or nil if bytesPerSecond is not positive i.e. no limit.
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		bytesPerSecond: float64(bytesPerSecond),
+		burst:          float64(bytesPerSecond),
+		tokens:         float64(bytesPerSecond),
+		last:           time.Now(),
+	}
+}
+
+// maxChunkSize returns the maximum number of bytes which should be transferred at once so that the rate stays smooth.
+func (l *rateLimiter) maxChunkSize() int {
+	return max(int(l.burst), 1)
+}
+
+// wait takes n tokens from the bucket and waits until the bucket is no longer in debt. It returns as soon as ctx is cancelled.
+func (l *rateLimiter) wait(ctx context.Context, n int) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil || n <= 0 {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.bytesPerSecond)
+	l.last = now
+	l.tokens -= float64(n)
+	delay := time.Duration(-l.tokens / l.bytesPerSecond * float64(time.Second))
+	l.mu.Unlock()
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		err = parallelisation.DetermineContextError(ctx)
+	case <-timer.C:
+	}
+	return
+}
+
+// reader returns a reader whose throughput is limited by l. r is returned as is if l is nil.
+func (l *rateLimiter) reader(ctx context.Context, r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+	return &rateLimitedReader{ctx: ctx, reader: r, limiter: l}
+}
+
+type rateLimitedReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rateLimiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (n int, err error) {
+	if len(p) > r.limiter.maxChunkSize() {
+		p = p[:r.limiter.maxChunkSize()]
+	}
+	n, err = r.reader.Read(p)
+	if waitErr := r.limiter.wait(r.ctx, n); waitErr != nil {
+		err = waitErr
+	}
+	return
+}
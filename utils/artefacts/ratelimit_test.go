@@ -0,0 +1,92 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/safeio"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("no limit", func(t *testing.T) {
+		assert.Nil(t, newRateLimiter(0))
+		assert.Nil(t, newRateLimiter(-1))
+		r := strings.NewReader(faker.Paragraph())
+		assert.Equal(t, r, newRateLimiter(0).reader(context.Background(), r))
+	})
+	t.Run("throughput", func(t *testing.T) {
+		limiter := newRateLimiter(1000)
+		content := bytes.Repeat([]byte{'a'}, 1500)
+		var wg sync.WaitGroup
+		start := time.Now()
+		// the limit is shared between concurrent copies: 3000 bytes with a burst of 1000 bytes take at least 2 seconds.
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var out bytes.Buffer
+				n, err := safeio.CopyDataWithContext(context.Background(), limiter.reader(context.Background(), bytes.NewReader(content)), &out)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(len(content)), n)
+			}()
+		}
+		wg.Wait()
+		assert.GreaterOrEqual(t, time.Since(start), 1900*time.Millisecond)
+	})
+	t.Run("options reused", func(t *testing.T) {
+		opts := []DownloadOption{WithRateLimit(500)}
+		first := newDownloadSession(faker.Word(), NewDownloadOptions(opts...))
+		second := newDownloadSession(faker.Word(), NewDownloadOptions(opts...))
+		require.NotNil(t, first.rateLimiter)
+		require.NotNil(t, second.rateLimiter)
+		assert.NotSame(t, first.rateLimiter, second.rateLimiter)
+	})
+	t.Run("cancellation", func(t *testing.T) {
+		limiter := newRateLimiter(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := io.Copy(io.Discard, limiter.reader(ctx, bytes.NewReader(bytes.Repeat([]byte{'a'}, 1000))))
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestDownloadWithRateLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, strings.Repeat("a", 600), true, false),
+		newTestArtefact(t, tmpDir, strings.Repeat("b", 600), false, false),
+	}
+	t.Run("limited", func(t *testing.T) {
+		out := t.TempDir()
+		start := time.Now()
+		report, err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithRateLimit(500))
+		require.NoError(t, err)
+		assert.Equal(t, len(artefacts), report.Downloaded)
+		assert.GreaterOrEqual(t, time.Since(start), 1300*time.Millisecond)
+	})
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		start := time.Now()
+		err := newTestArtefactsManager(t, artefacts, false).DownloadAllJobArtefactsWithOptions(ctx, faker.Word(), t.TempDir(), WithRateLimit(10))
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+)
+
+// DownloadStatus describes the outcome of an artefact download.
+type DownloadStatus int
+
+const (
+	// DownloadStatusFailed states that the artefact could not be downloaded.
+	DownloadStatusFailed DownloadStatus = iota
+	// DownloadStatusDownloaded states that the artefact was successfully downloaded and verified.
+	DownloadStatusDownloaded
+	// DownloadStatusSkipped states that the artefact was deliberately not downloaded.
+	DownloadStatusSkipped
+)
+
+func (s DownloadStatus) String() string {
+	switch s {
+	case DownloadStatusDownloaded:
+		return "downloaded"
+	case DownloadStatusSkipped:
+		return "skipped"
+	default:
+		return "failed"
+	}
+}
+
+// ArtefactReport describes what happened to a particular artefact during a download.
+type ArtefactReport struct {
+	// Name is the name of the artefact.
+	Name string
+	// Destination is the path where the artefact was stored. If artefacts were downloaded into an archive, it is the path of the entry in the archive.
+	// It is empty if the artefact was an archive which was deleted once extracted.
+	Destination string
+	// RelativePath is the slash-separated path of the artefact relative to the root of the destination e.g. the output directory.
+	RelativePath string
+	// ExtractedTo is the path of the directory the artefact was extracted to if it was an archive and extraction was requested (see WithExtractArchives).
+	ExtractedTo string
+	// OriginalDestination is the path where the artefact would have been stored if it had not been renamed to avoid a collision. It is empty if the artefact was not renamed.
+	OriginalDestination string
+	// Size is the size in bytes of the artefact.
+	Size int64
+	// Hash is the hexadecimal digest of the artefact content which was verified.
+	Hash string
+	// HashAlgorithm is the algorithm used to verify the artefact content (see SupportedHashAlgorithms).
+	HashAlgorithm string
+	// FromCache states whether the artefact was retrieved from the artefact cache rather than fetched (see WithCache).
+	FromCache bool
+	// Attempts is the number of times the artefact content was fetched. It is greater than 1 if the artefact had to be downloaded again (see WithRetryPolicy).
+	Attempts int
+	// Duration is the time it took to process the artefact.
+	Duration time.Duration
+	// Status is the outcome of the download.
+	Status DownloadStatus
+	// Err is the reason why the artefact could not be downloaded, if it failed.
+	Err error
+}
+
+// DownloadReport describes the outcome of the download of a job's artefacts.
+type DownloadReport struct {
+	// Job is the name of the job.
+	Job string
+	// Artefacts lists what happened to each artefact in the order they were processed.
+	Artefacts []ArtefactReport
+	// Downloaded is the number of artefacts successfully downloaded.
+	Downloaded int
+	// Skipped is the number of artefacts which were not downloaded again as they did not change since they were last downloaded (see FollowJobArtefacts).
+	Skipped int
+	// Failed is the number of artefacts which could not be downloaded.
+	Failed int
+	// TotalSize is the total number of bytes downloaded.
+	TotalSize int64
+	// Duration is the time it took to process all the artefacts.
+	Duration time.Duration
+}
+
+func newDownloadReport(jobName string) *DownloadReport {
+	return &DownloadReport{Job: jobName}
+}
+
+// record adds the outcome of an artefact download to the report. If the artefact was already processed e.g. because it changed, its previous outcome is replaced.
+func (r *DownloadReport) record(artefact ArtefactReport) {
+	if r == nil {
+		return
+	}
+	r.count(&artefact, 1)
+	if artefact.Name != "" {
+		for i := range r.Artefacts {
+			if r.Artefacts[i].Name == artefact.Name {
+				r.count(&r.Artefacts[i], -1)
+				r.Artefacts[i] = artefact
+				return
+			}
+		}
+	}
+	r.Artefacts = append(r.Artefacts, artefact)
+}
+
+// skip records that an artefact was deliberately not downloaded e.g. because it did not change since it was last downloaded. Details of any previous download of the artefact, such as its destination, are kept.
+func (r *DownloadReport) skip(artefactName string) {
+	if r == nil {
+		return
+	}
+	artefact := ArtefactReport{Name: artefactName}
+	for i := range r.Artefacts {
+		if r.Artefacts[i].Name == artefactName {
+			artefact = r.Artefacts[i]
+			break
+		}
+	}
+	artefact.Status = DownloadStatusSkipped
+	artefact.Err = nil
+	artefact.Attempts = 0
+	artefact.Duration = 0
+	r.record(artefact)
+}
+
+func (r *DownloadReport) count(artefact *ArtefactReport, increment int) {
+	switch artefact.Status {
+	case DownloadStatusDownloaded:
+		r.Downloaded += increment
+		r.TotalSize += int64(increment) * artefact.Size
+	case DownloadStatusSkipped:
+		r.Skipped += increment
+	default:
+		r.Failed += increment
+	}
+}
+
+// HasFailures states whether some artefacts could not be downloaded.
+func (r *DownloadReport) HasFailures() bool {
+	return r != nil && r.Failed > 0
+}
+
+// FailedArtefacts returns the reports of all the artefacts which could not be downloaded.
+func (r *DownloadReport) FailedArtefacts() (failed []ArtefactReport) {
+	if r == nil {
+		return
+	}
+	for i := range r.Artefacts {
+		if r.Artefacts[i].Status == DownloadStatusFailed {
+			failed = append(failed, r.Artefacts[i])
+		}
+	}
+	return
+}
+
+// Errors returns all the errors encountered during the download joined together or nil if there were none.
+func (r *DownloadReport) Errors() error {
+	var errs []error
+	for _, a := range r.FailedArtefacts() {
+		if a.Err != nil {
+			errs = append(errs, a.Err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return commonerrors.Join(errs...)
+}
@@ -0,0 +1,356 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package artefacts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sync"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/store"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+)
+
+// ArtefactWriterFactory returns the writer an artefact stored at relativePath should be written to.
+// If the writer is also an io.Closer, it is closed once the artefact has been processed.
+type ArtefactWriterFactory = func(ctx context.Context, relativePath string) (io.Writer, error)
+
+type filesystemSink struct {
+	fs   filesystem.FS
+	root string
+}
+
+func (s *filesystemSink) Location(relativePath string) string {
+	return filesystem.FilePathJoin(s.fs, s.root, filesystem.FilePathFromSlash(s.fs, relativePath))
+}
+
+func (s *filesystemSink) Create(ctx context.Context, relativePath string) (w IArtefactWriter, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	destination, err := s.prepareDestination(relativePath)
+	if err != nil {
+		return
+	}
+	destinationDir := filesystem.FilePathDir(s.fs, destination)
+	file, err := s.fs.TempFile(destinationDir, fmt.Sprintf(".%v.partial-*", filesystem.FilePathBase(s.fs, destination)))
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not create a location to store artefact [%v]", relativePath)
+		return
+	}
+	w = &fileArtefactWriter{fs: s.fs, file: file, destination: destination}
+	return
+}
+
+// prepareDestination determines where an artefact stored at relativePath should be written and creates the directories needed.
+func (s *filesystemSink) prepareDestination(relativePath string) (destination string, err error) {
+	cleanedPath, err := sanitiseRelativePath(relativePath)
+	if err != nil {
+		return
+	}
+	if cleanedPath == "" {
+		err = commonerrors.UndefinedVariable("artefact path")
+		return
+	}
+	err = s.fs.MkDir(s.root)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed creating the output directory [%v] for job artefact", s.root)
+		return
+	}
+	destination = s.Location(cleanedPath)
+	if s.fs.GetType() == filesystem.StandardFS {
+		err = checkDestinationIsWithinDirectory(s.root, destination)
+		if err != nil {
+			return
+		}
+	}
+	destinationDir := filesystem.FilePathDir(s.fs, destination)
+	err = s.fs.MkDir(destinationDir)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "failed creating the output directory [%v] for job artefact", destinationDir)
+		return
+	}
+	return
+}
+
+// link stores the artefact at relativePath as a hard link to sourcePath. Both files must be on the same filesystem.
+func (s *filesystemSink) link(ctx context.Context, relativePath string, fs filesystem.FS, sourcePath string) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	if fs == nil || fs.GetType() != s.fs.GetType() {
+		err = commonerrors.New(commonerrors.ErrUnsupported, "artefacts can only be linked to files of the same filesystem")
+		return
+	}
+	destination, err := s.prepareDestination(relativePath)
+	if err != nil {
+		return
+	}
+	if s.fs.Exists(destination) {
+		err = s.fs.Rm(destination)
+		if err != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not replace artefact at [%v]", destination)
+			return
+		}
+	}
+	err = s.fs.Link(sourcePath, destination)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not link artefact at [%v]", destination)
+	}
+	return
+}
+
+func (s *filesystemSink) remove(ctx context.Context, relativePath string) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	cleanedPath, err := sanitiseRelativePath(relativePath)
+	if err != nil || cleanedPath == "" {
+		return
+	}
+	destination := s.Location(cleanedPath)
+	err = s.fs.Rm(destination)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not remove artefact at [%v]", destination)
+		return
+	}
+	// directories created for the file are only removed if nothing else was stored in them.
+	root := filesystem.FilePathClean(s.fs, s.root)
+	for dir := filesystem.FilePathDir(s.fs, destination); dir != root && len(dir) > len(root); dir = filesystem.FilePathDir(s.fs, dir) {
+		empty, subErr := s.fs.IsEmpty(dir)
+		if subErr != nil || !empty {
+			return
+		}
+		if subErr = s.fs.Rm(dir); subErr != nil {
+			return
+		}
+	}
+	return
+}
+
+func (s *filesystemSink) Close(context.Context) error {
+	return nil
+}
+
+// fileArtefactWriter writes an artefact to a temporary file which is only moved to its destination once committed.
+type fileArtefactWriter struct {
+	fs          filesystem.FS
+	file        filesystem.File
+	destination string
+	attributes  *artefactAttributes
+	done        bool
+}
+
+func (w *fileArtefactWriter) setAttributes(attributes *artefactAttributes) {
+	w.attributes = attributes
+}
+
+func (w *fileArtefactWriter) Write(p []byte) (int, error) {
+	return w.file.Write(p)
+}
+
+func (w *fileArtefactWriter) Commit(context.Context) (err error) {
+	if w.done {
+		return
+	}
+	w.done = true
+	err = w.file.Close()
+	if err == nil {
+		err = w.applyAttributes(w.file.Name())
+	}
+	if err == nil {
+		err = w.fs.Move(w.file.Name(), w.destination)
+	}
+	if err != nil {
+		_ = w.fs.Rm(w.file.Name())
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not store artefact at [%v]", w.destination)
+	}
+	return
+}
+
+func (w *fileArtefactWriter) applyAttributes(filePath string) (err error) {
+	if w.attributes.isEmpty() {
+		return
+	}
+	if w.attributes.hasMode {
+		err = w.fs.Chmod(filePath, w.attributes.mode)
+		if err != nil {
+			return
+		}
+	}
+	if !w.attributes.modTime.IsZero() {
+		err = w.fs.Chtimes(filePath, w.attributes.modTime, w.attributes.modTime)
+	}
+	return
+}
+
+func (w *fileArtefactWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	_ = w.file.Close()
+	return w.fs.Rm(w.file.Name())
+}
+
+// NewDirectorySink returns a sink storing artefacts in a directory of the local filesystem.
+// Artefacts cannot be written outside the directory, including through symbolic links.
+func NewDirectorySink(directory string) IArtefactSink {
+	return NewFilesystemSink(filesystem.GetGlobalFileSystem(), directory)
+}
+
+// NewFilesystemSink returns a sink storing artefacts in the root directory of any filesystem e.g. an in-memory filesystem.
+func NewFilesystemSink(fs filesystem.FS, root string) IArtefactSink {
+	return &filesystemSink{fs: fs, root: root}
+}
+
+// iPreparedSink is implemented by sinks which must be set up before the location of artefacts can be determined.
+type iPreparedSink interface {
+	// prepare sets the sink up.
+	prepare(ctx context.Context) error
+}
+
+type storeSink struct {
+	mu    sync.Mutex
+	store store.IStore
+}
+
+func (s *storeSink) Location(relativePath string) string {
+	return s.filesystemSink().Location(relativePath)
+}
+
+// prepare creates the store before anything is downloaded as the path of some stores is only known once they are created.
+func (s *storeSink) prepare(ctx context.Context) error {
+	return s.ensureExists(ctx)
+}
+
+func (s *storeSink) Create(ctx context.Context, relativePath string) (w IArtefactWriter, err error) {
+	err = s.ensureExists(ctx)
+	if err != nil {
+		return
+	}
+	w, err = s.filesystemSink().Create(ctx, relativePath)
+	return
+}
+
+func (s *storeSink) link(ctx context.Context, relativePath string, fs filesystem.FS, sourcePath string) (err error) {
+	err = s.ensureExists(ctx)
+	if err != nil {
+		return
+	}
+	err = s.filesystemSink().link(ctx, relativePath, fs, sourcePath)
+	return
+}
+
+func (s *storeSink) remove(ctx context.Context, relativePath string) error {
+	return s.filesystemSink().remove(ctx, relativePath)
+}
+
+func (s *storeSink) ensureExists(ctx context.Context) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.store.Exists() {
+		return
+	}
+	err = s.store.Create(ctx)
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not set up artefact store")
+	}
+	return
+}
+
+func (s *storeSink) Close(context.Context) error {
+	return nil
+}
+
+func (s *storeSink) filesystemSink() *filesystemSink {
+	return &filesystemSink{fs: s.store.GetFilesystem(), root: s.store.GetPath()}
+}
+
+// NewStoreSink returns a sink storing artefacts in a store. The store is created if it does not exist yet.
+// The sink does not close the store.
+func NewStoreSink(artefactStore store.IStore) IArtefactSink {
+	return &storeSink{store: artefactStore}
+}
+
+type writerSink struct {
+	factory ArtefactWriterFactory
+}
+
+func (s *writerSink) Location(relativePath string) string {
+	return relativePath
+}
+
+func (s *writerSink) Create(ctx context.Context, relativePath string) (w IArtefactWriter, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	if s.factory == nil {
+		err = commonerrors.UndefinedVariable("artefact writer factory")
+		return
+	}
+	writer, err := s.factory(ctx, path.Clean(relativePath))
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not get a writer for artefact [%v]", relativePath)
+		return
+	}
+	if writer == nil {
+		err = commonerrors.UndefinedVariable("artefact writer")
+		return
+	}
+	w = &streamArtefactWriter{writer: writer}
+	return
+}
+
+func (s *writerSink) Close(context.Context) error {
+	return nil
+}
+
+type streamArtefactWriter struct {
+	writer io.Writer
+	done   bool
+}
+
+func (w *streamArtefactWriter) Write(p []byte) (int, error) {
+	return w.writer.Write(p)
+}
+
+func (w *streamArtefactWriter) Commit(context.Context) error {
+	return w.close()
+}
+
+func (w *streamArtefactWriter) Abort() error {
+	return w.close()
+}
+
+func (w *streamArtefactWriter) close() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	if closer, ok := w.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// NewWriterSink returns a sink streaming each artefact to the writer provided by factory.
+// As content is streamed directly, writers may receive the content of artefacts which are later found to be invalid.
+func NewWriterSink(factory ArtefactWriterFactory) IArtefactSink {
+	return &writerSink{factory: factory}
+}
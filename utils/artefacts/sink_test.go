@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package artefacts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/store"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+type testBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *testBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func newTestSinkArtefacts(t *testing.T) []*testArtefact {
+	tmpDir := t.TempDir()
+	var artefacts []*testArtefact
+	for i := 0; i < 3; i++ {
+		artefacts = append(artefacts, newTestArtefact(t, tmpDir, faker.Paragraph(), true, false))
+	}
+	return artefacts
+}
+
+func TestFilesystemSink(t *testing.T) {
+	artefacts := newTestSinkArtefacts(t)
+	m := newTestArtefactsManager(t, artefacts, false)
+	fs := filesystem.NewInMemoryFileSystem()
+	root := filepath.Join(faker.Word(), faker.Word())
+	out := filepath.Join(t.TempDir(), faker.Word())
+
+	report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithSink(NewFilesystemSink(fs, root)), WithManifest(true))
+	require.NoError(t, err)
+	assert.NoDirExists(t, out)
+	for i := range artefacts {
+		expected, err := filesystem.ReadFile(artefacts[i].path)
+		require.NoError(t, err)
+		destination := filesystem.FilePathJoin(fs, root, artefacts[i].name)
+		assert.Equal(t, destination, report.Artefacts[i].Destination)
+		actual, err := fs.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+	assert.True(t, fs.Exists(filesystem.FilePathJoin(fs, root, DefaultManifestFileName)))
+	files, err := fs.Ls(root)
+	require.NoError(t, err)
+	assert.Len(t, files, len(artefacts)+2)
+}
+
+func TestDirectorySinkAbort(t *testing.T) {
+	tmpDir := t.TempDir()
+	artefacts := []*testArtefact{
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, false),
+		newTestArtefact(t, tmpDir, faker.Paragraph(), true, true),
+	}
+	m := newTestArtefactsManager(t, artefacts, false)
+	out := t.TempDir()
+	report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), out, WithStopOnFirstError(false))
+	require.Error(t, err)
+	assert.Equal(t, 1, report.Downloaded)
+	assert.Equal(t, 1, report.Failed)
+	files, err := filesystem.Ls(out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{artefacts[0].name}, files)
+
+	sink := NewDirectorySink(out)
+	w, err := sink.Create(context.Background(), "test.txt")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(faker.Sentence()))
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(out, "test.txt"))
+	require.NoError(t, w.Abort())
+	require.NoError(t, w.Commit(context.Background()))
+	assert.NoFileExists(t, filepath.Join(out, "test.txt"))
+	files, err = filesystem.Ls(out)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	_, err = sink.Create(context.Background(), "../test.txt")
+	errortest.AssertError(t, err, commonerrors.ErrInvalid)
+}
+
+func TestWriterSink(t *testing.T) {
+	artefacts := newTestSinkArtefacts(t)
+	m := newTestArtefactsManager(t, artefacts, false)
+	var mu sync.Mutex
+	buffers := map[string]*testBuffer{}
+	sink := NewWriterSink(func(_ context.Context, relativePath string) (io.Writer, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		b := &testBuffer{}
+		buffers[relativePath] = b
+		return b, nil
+	})
+	_, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), "", WithSink(sink))
+	require.NoError(t, err)
+	require.Len(t, buffers, len(artefacts))
+	for i := range artefacts {
+		expected, err := filesystem.ReadFile(artefacts[i].path)
+		require.NoError(t, err)
+		require.Contains(t, buffers, artefacts[i].name)
+		assert.Equal(t, expected, buffers[artefacts[i].name].Bytes())
+		assert.True(t, buffers[artefacts[i].name].closed)
+	}
+
+	_, err = NewWriterSink(nil).Create(context.Background(), faker.Word())
+	errortest.AssertError(t, err, commonerrors.ErrUndefined)
+}
+
+func TestStoreSink(t *testing.T) {
+	artefacts := newTestSinkArtefacts(t)
+	m := newTestArtefactsManager(t, artefacts, false)
+	s := store.NewLocalTemporaryStore()
+	defer func() { _ = s.Close() }()
+	report, err := m.DownloadAllJobArtefactsWithReport(context.Background(), faker.Word(), "", WithSink(NewStoreSink(s)), WithMaintainStructure(true))
+	require.NoError(t, err)
+	require.True(t, s.Exists())
+	for i := range artefacts {
+		assert.True(t, s.HasElement(artefacts[i].name))
+		assert.Equal(t, filepath.Join(s.GetPath(), artefacts[i].name), report.Artefacts[i].Destination)
+		expected, err := filesystem.ReadFile(artefacts[i].path)
+		require.NoError(t, err)
+		actual, err := filesystem.ReadFile(report.Artefacts[i].Destination)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+	t.Run("location does not create the store", func(t *testing.T) {
+		s := store.NewLocalStore(filepath.Join(t.TempDir(), faker.Word()))
+		sink := NewStoreSink(s)
+		assert.Equal(t, filepath.Join(s.GetPath(), artefacts[0].name), sink.Location(artefacts[0].name))
+		assert.False(t, s.Exists())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := m.DownloadAllJobArtefactsWithReport(ctx, faker.Word(), "", WithSink(sink))
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+		assert.False(t, s.Exists())
+	})
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity describes the severity of a diagnostic as stated by the compiler, from the least to the most severe.
+type Severity int
+
+const (
+	// SeverityNote corresponds to notes giving more details about another diagnostic.
+	SeverityNote Severity = iota
+	// SeverityRemark corresponds to remarks e.g. Arm Compiler remarks or Clang optimisation remarks.
+	SeverityRemark
+	// SeverityWarning corresponds to warnings.
+	SeverityWarning
+	// SeverityError corresponds to errors.
+	SeverityError
+	// SeverityFatal corresponds to errors stopping compilation.
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityNote:
+		return "note"
+	case SeverityRemark:
+		return "remark"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// ParseSeverity determines the severity corresponding to a severity as stated by GCC, Clang or Arm Compiler e.g. `warning`, `Fatal error` or `Mandatory remark`. Comparison is case-insensitive.
+// ok is false if the severity is unknown.
+func ParseSeverity(severity string) (s Severity, ok bool) {
+	ok = true
+	switch strings.Join(strings.Fields(strings.ToLower(severity)), " ") {
+	case "note":
+		s = SeverityNote
+	case "remark", "mandatory remark":
+		s = SeverityRemark
+	case "warning":
+		s = SeverityWarning
+	case "error":
+		s = SeverityError
+	case "fatal error", "fatal":
+		s = SeverityFatal
+	default:
+		ok = false
+	}
+	return
+}
+
+// Diagnostic describes an issue reported by a compiler about a source file.
+type Diagnostic struct {
+	// File is the path of the source file the diagnostic relates to, once mapped to a local path (see WithPathMapping).
+	File string
+	// ReportedFile is the path of the source file as reported by the compiler in the service workspace.
+	ReportedFile string
+	// Line is the line of the source file the diagnostic relates to, starting from 1.
+	Line int
+	// Column is the column of the source file the diagnostic relates to, starting from 1, or 0 if not reported.
+	Column int
+	// Severity is the severity of the diagnostic.
+	Severity Severity
+	// Code identifies the kind of diagnostic e.g. `-Wunused-variable` or `#177-D`, if reported.
+	Code string
+	// Text is the description of the diagnostic.
+	Text string
+}
+
+// String lays the diagnostic out as GCC and Clang do e.g. `src/main.c:12:5: warning: unused variable 'x' [-Wunused-variable]`, which is understood by most IDEs.
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%v:%d:", d.File, d.Line)
+	if d.Column > 0 {
+		_, _ = fmt.Fprintf(&b, "%d:", d.Column)
+	}
+	_, _ = fmt.Fprintf(&b, " %v: %v", d.Severity, d.Text)
+	if d.Code != "" {
+		_, _ = fmt.Fprintf(&b, " [%v]", d.Code)
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package diagnostics provides utilities to extract compiler diagnostics e.g. errors and warnings, from the messages of build jobs.
+package diagnostics
+
+import (
+	"context"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/messages"
+	"github.com/ARM-software/golang-utils/utils/collection/pagination"
+)
+
+// Mocks are generated using `go generate ./...`
+// Add interfaces to the following command for a mock to be generated
+//go:generate go tool mockgen -destination=../mocks/mock_$GOPACKAGE.go -package=mocks github.com/ARM-software/embedded-development-services-client-utils/utils/$GOPACKAGE IParser
+
+// IParser defines a parser extracting compiler diagnostics from service messages.
+type IParser interface {
+	// ParseLine extracts the diagnostic reported by a line of compiler output, if any.
+	ParseLine(line string) (diagnostic *Diagnostic, ok bool)
+	// ParseMessage extracts all the diagnostics reported by a message, in the order they appear.
+	ParseMessage(msg messages.IMessage) []Diagnostic
+	// ParseMessagesCollection extracts the diagnostics reported by all the messages of a collection e.g. the messages of a build job, and passes each of them to handler as soon as it is found.
+	// Elements of the collection which are not messages are ignored.
+	ParseMessagesCollection(ctx context.Context, messagePaginator pagination.IGenericPaginator, handler func(Diagnostic)) error
+}
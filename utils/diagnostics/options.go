@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package diagnostics
+
+// PathMapping describes how paths of the service workspace translate into local paths.
+type PathMapping struct {
+	// ServicePath is a directory of the service workspace e.g. `/workspace/project`.
+	ServicePath string
+	// LocalPath is the local directory corresponding to ServicePath e.g. the directory the project was uploaded from.
+	LocalPath string
+}
+
+type ParserOptions struct {
+	PathMappings            []PathMapping
+	ServiceWorkingDirectory string
+}
+
+type ParserOption func(*ParserOptions)
+
+func NewParserOptions(opts ...ParserOption) (options *ParserOptions) {
+	options = &ParserOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return
+}
+
+// WithPathMapping specifies that files reported within servicePath, a directory of the service workspace, should be located within localPath instead e.g. so that IDEs or CI annotations point at local sources.
+// When several mappings apply to a file, the one with the longest service path is used. Files which no mapping applies to are left as reported.
+func WithPathMapping(servicePath, localPath string) ParserOption {
+	return func(o *ParserOptions) {
+		o.PathMappings = append(o.PathMappings, PathMapping{ServicePath: servicePath, LocalPath: localPath})
+	}
+}
+
+// WithServiceWorkingDirectory specifies the directory of the service workspace the compiler was run from, which relative paths reported by the compiler are resolved against before any path mapping is applied.
+func WithServiceWorkingDirectory(directory string) ParserOption {
+	return func(o *ParserOptions) {
+		o.ServiceWorkingDirectory = directory
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/logging"
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/messages"
+	"github.com/ARM-software/golang-utils/utils/collection/pagination"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/field"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+	"github.com/ARM-software/golang-utils/utils/reflection"
+)
+
+var (
+	// gccDiagnostic matches diagnostics as reported by GCC, Clang and armclang e.g. `src/main.c:12:5: warning: unused variable 'x' [-Wunused-variable]`. The column is optional.
+	gccDiagnostic = regexp.MustCompile(`^(?P<file>(?:[A-Za-z]:)?[^:]+?):(?P<line>\d+):(?:(?P<column>\d+):)?\s*(?P<severity>(?i:fatal error|error|warning|note|remark))\s*:\s*(?P<text>.*)$`)
+	// msvcDiagnostic matches diagnostics as reported by Clang and armclang with `-fdiagnostics-format=msvc` e.g. `src\main.c(12,5): warning: unused variable 'x' [-Wunused-variable]`.
+	msvcDiagnostic = regexp.MustCompile(`^(?P<file>[^(]+?)\((?P<line>\d+)(?:,(?P<column>\d+))?\)\s*:\s*(?P<severity>(?i:fatal error|error|warning|note|remark))\s*(?P<code>[A-Za-z]+\d+)?\s*:\s*(?P<text>.*)$`)
+	// armccDiagnostic matches diagnostics as reported by Arm Compiler 5 e.g. `"src/main.c", line 12 (column 5): Warning:  #177-D: variable "x" was declared but never referenced`.
+	armccDiagnostic = regexp.MustCompile(`^"(?P<file>[^"]+)", line (?P<line>\d+)(?: \(column (?P<column>\d+)\))?:\s*(?P<severity>(?i:fatal error|error|warning|mandatory remark|remark))\s*:\s*(?:(?P<code>#\d+(?:-[A-Z])?|[A-Z]\d{4}[A-Z])\s*:\s*)?(?P<text>.*)$`)
+	// warningFlag matches the flag controlling a diagnostic, as appended by GCC and Clang e.g. ` [-Wunused-variable]` or ` [-Werror,-Wunused-variable]`.
+	warningFlag = regexp.MustCompile(`\s+\[(-W[^\]\s]+)\]$`)
+
+	diagnosticPatterns = []*regexp.Regexp{gccDiagnostic, msvcDiagnostic, armccDiagnostic}
+)
+
+// Parser extracts compiler diagnostics from service messages and maps the files they relate to onto local paths.
+type Parser struct {
+	mappings         []PathMapping
+	workingDirectory string
+}
+
+// NewParser returns a parser recognising diagnostics as reported by GCC, Clang and Arm Compiler (armclang and armcc).
+func NewParser(opts ...ParserOption) (parser IParser, err error) {
+	options := NewParserOptions(opts...)
+	p := &Parser{}
+	if !reflection.IsEmpty(options.ServiceWorkingDirectory) {
+		p.workingDirectory = cleanServicePath(options.ServiceWorkingDirectory)
+	}
+	for i := range options.PathMappings {
+		mapping := options.PathMappings[i]
+		if reflection.IsEmpty(mapping.ServicePath) {
+			err = commonerrors.UndefinedVariable("service path to map")
+			return
+		}
+		if reflection.IsEmpty(mapping.LocalPath) {
+			err = commonerrors.UndefinedVariable("local path to map to")
+			return
+		}
+		p.mappings = append(p.mappings, PathMapping{ServicePath: cleanServicePath(mapping.ServicePath), LocalPath: filepath.Clean(mapping.LocalPath)})
+	}
+	// the most specific mappings are tried first.
+	sort.SliceStable(p.mappings, func(i, j int) bool {
+		return len(p.mappings[i].ServicePath) > len(p.mappings[j].ServicePath)
+	})
+	parser = p
+	return
+}
+
+func (p *Parser) ParseLine(line string) (diagnostic *Diagnostic, ok bool) {
+	// compilers may colour their output.
+	line = strings.TrimSpace(logging.StripANSIEscapeSequences(line))
+	for _, pattern := range diagnosticPatterns {
+		diagnostic, ok = p.parseLine(pattern, line)
+		if ok {
+			return
+		}
+	}
+	return
+}
+
+func (p *Parser) parseLine(pattern *regexp.Regexp, line string) (diagnostic *Diagnostic, ok bool) {
+	match := pattern.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+	group := func(name string) string {
+		if i := pattern.SubexpIndex(name); i >= 0 {
+			return strings.TrimSpace(match[i])
+		}
+		return ""
+	}
+	severity, known := ParseSeverity(group("severity"))
+	if !known {
+		return
+	}
+	lineNumber, err := strconv.Atoi(group("line"))
+	if err != nil {
+		return
+	}
+	column := 0
+	if c := group("column"); c != "" {
+		column, err = strconv.Atoi(c)
+		if err != nil {
+			return
+		}
+	}
+	reportedFile := group("file")
+	diagnostic = &Diagnostic{
+		File:         p.mapPath(reportedFile),
+		ReportedFile: reportedFile,
+		Line:         lineNumber,
+		Column:       column,
+		Severity:     severity,
+		Code:         group("code"),
+		Text:         group("text"),
+	}
+	if diagnostic.Code == "" {
+		diagnostic.Text, diagnostic.Code = extractWarningFlag(diagnostic.Text)
+	}
+	ok = true
+	return
+}
+
+// extractWarningFlag removes the flag controlling a diagnostic from its text e.g. `unused variable 'x' [-Werror,-Wunused-variable]` gives `-Wunused-variable`.
+func extractWarningFlag(text string) (remainder string, flag string) {
+	remainder = text
+	match := warningFlag.FindStringSubmatchIndex(text)
+	if match == nil {
+		return
+	}
+	flags := strings.Split(text[match[2]:match[3]], ",")
+	flag = flags[len(flags)-1]
+	// GCC states warnings turned into errors as `-Werror=<warning>`.
+	if warning, found := strings.CutPrefix(flag, "-Werror="); found {
+		flag = "-W" + warning
+	}
+	remainder = strings.TrimSpace(text[:match[0]])
+	return
+}
+
+// cleanServicePath normalises a path of the service workspace so that it is slash-separated whatever the platform.
+func cleanServicePath(p string) string {
+	return path.Clean(strings.ReplaceAll(p, `\`, "/"))
+}
+
+// isAbsoluteServicePath states whether a slash-separated path of the service workspace is absolute, whether the workspace is on Linux e.g. `/workspace/main.c` or Windows e.g. `C:/workspace/main.c`.
+func isAbsoluteServicePath(p string) bool {
+	return path.IsAbs(p) || (len(p) > 2 && p[1] == ':' && p[2] == '/')
+}
+
+// mapPath determines the local path of a file reported by the compiler. Files which no mapping applies to are left as reported, unless they had to be resolved against the service working directory.
+func (p *Parser) mapPath(reportedFile string) (localPath string) {
+	localPath = reportedFile
+	servicePath := cleanServicePath(reportedFile)
+	if !isAbsoluteServicePath(servicePath) && !reflection.IsEmpty(p.workingDirectory) {
+		servicePath = path.Join(p.workingDirectory, servicePath)
+		localPath = servicePath
+	}
+	for i := range p.mappings {
+		if servicePath == p.mappings[i].ServicePath {
+			return p.mappings[i].LocalPath
+		}
+		if relativePath, found := strings.CutPrefix(servicePath, strings.TrimSuffix(p.mappings[i].ServicePath, "/")+"/"); found {
+			return filepath.Join(p.mappings[i].LocalPath, filepath.FromSlash(relativePath))
+		}
+	}
+	return
+}
+
+func (p *Parser) ParseMessage(msg messages.IMessage) (diagnostics []Diagnostic) {
+	if msg == nil {
+		return
+	}
+	text, ok := msg.GetMessageOk()
+	if !ok {
+		return
+	}
+	for _, line := range strings.Split(field.OptionalString(text, ""), "\n") {
+		if diagnostic, found := p.ParseLine(line); found {
+			diagnostics = append(diagnostics, *diagnostic)
+		}
+	}
+	return
+}
+
+func (p *Parser) ParseMessagesCollection(ctx context.Context, messagePaginator pagination.IGenericPaginator, handler func(Diagnostic)) error {
+	if handler == nil {
+		return commonerrors.UndefinedVariable("diagnostic handler")
+	}
+	for {
+		err := parallelisation.DetermineContextError(ctx)
+		if err != nil {
+			return err
+		}
+		if messagePaginator == nil {
+			return commonerrors.UndefinedVariable("paginator")
+		}
+		if !messagePaginator.HasNext() {
+			return nil
+		}
+		m, err := messagePaginator.GetNext()
+		if err != nil {
+			return err
+		}
+		msg, ok := m.(messages.IMessage)
+		if !ok || reflection.IsEmpty(msg) {
+			continue
+		}
+		for _, diagnostic := range p.ParseMessage(msg) {
+			handler(diagnostic)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package diagnostics
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client/client"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+)
+
+// testPaginator iterates over a fixed list of items.
+type testPaginator struct {
+	items []any
+}
+
+func (p *testPaginator) HasNext() bool {
+	return len(p.items) > 0
+}
+
+func (p *testPaginator) GetNext() (item any, err error) {
+	if len(p.items) == 0 {
+		err = commonerrors.ErrEmpty
+		return
+	}
+	item, p.items = p.items[0], p.items[1:]
+	return
+}
+
+func (p *testPaginator) Close() error {
+	return nil
+}
+
+func (p *testPaginator) Stop() context.CancelFunc {
+	return func() {}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for severity, expected := range map[string]Severity{
+		"note":             SeverityNote,
+		"Remark":           SeverityRemark,
+		"Mandatory remark": SeverityRemark,
+		"warning":          SeverityWarning,
+		"ERROR":            SeverityError,
+		"fatal error":      SeverityFatal,
+		"Fatal  error":     SeverityFatal,
+	} {
+		s, ok := ParseSeverity(severity)
+		assert.True(t, ok, severity)
+		assert.Equal(t, expected, s, severity)
+	}
+	_, ok := ParseSeverity(faker.Word() + "x")
+	assert.False(t, ok)
+	assert.Equal(t, "fatal error", SeverityFatal.String())
+	assert.Equal(t, "severity(12)", Severity(12).String())
+}
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected *Diagnostic
+	}{
+		{
+			line:     "src/main.c:12:5: warning: unused variable 'x' [-Wunused-variable]",
+			expected: &Diagnostic{File: "src/main.c", ReportedFile: "src/main.c", Line: 12, Column: 5, Severity: SeverityWarning, Code: "-Wunused-variable", Text: "unused variable 'x'"},
+		},
+		{
+			line:     "/workspace/src/main.c:3:10: fatal error: foo.h: No such file or directory",
+			expected: &Diagnostic{File: "/workspace/src/main.c", ReportedFile: "/workspace/src/main.c", Line: 3, Column: 10, Severity: SeverityFatal, Text: "foo.h: No such file or directory"},
+		},
+		{
+			line:     "main.c:7: error: 'y' undeclared [-Werror=implicit-function-declaration]",
+			expected: &Diagnostic{File: "main.c", ReportedFile: "main.c", Line: 7, Severity: SeverityError, Code: "-Wimplicit-function-declaration", Text: "'y' undeclared"},
+		},
+		{
+			line:     "  \x1b[1mmain.c:8:1: \x1b[0;1;35mwarning: \x1b[0m\x1b[1mnon-void function does not return a value [-Werror,-Wreturn-type]\x1b[0m",
+			expected: &Diagnostic{File: "main.c", ReportedFile: "main.c", Line: 8, Column: 1, Severity: SeverityWarning, Code: "-Wreturn-type", Text: "non-void function does not return a value"},
+		},
+		{
+			line:     "main.c:2:9: note: previous definition is here",
+			expected: &Diagnostic{File: "main.c", ReportedFile: "main.c", Line: 2, Column: 9, Severity: SeverityNote, Text: "previous definition is here"},
+		},
+		{
+			line:     `C:\work\main.c:4:2: error: expected ';' after expression`,
+			expected: &Diagnostic{File: `C:\work\main.c`, ReportedFile: `C:\work\main.c`, Line: 4, Column: 2, Severity: SeverityError, Text: "expected ';' after expression"},
+		},
+		{
+			line:     `src\main.c(12,5): warning: unused variable 'x' [-Wunused-variable]`,
+			expected: &Diagnostic{File: `src\main.c`, ReportedFile: `src\main.c`, Line: 12, Column: 5, Severity: SeverityWarning, Code: "-Wunused-variable", Text: "unused variable 'x'"},
+		},
+		{
+			line:     `"src/main.c", line 12 (column 5): Warning:  #177-D: variable "x" was declared but never referenced`,
+			expected: &Diagnostic{File: "src/main.c", ReportedFile: "src/main.c", Line: 12, Column: 5, Severity: SeverityWarning, Code: "#177-D", Text: `variable "x" was declared but never referenced`},
+		},
+		{
+			line:     `"main.c", line 3: Error:  #20: identifier "y" is undefined`,
+			expected: &Diagnostic{File: "main.c", ReportedFile: "main.c", Line: 3, Severity: SeverityError, Code: "#20", Text: `identifier "y" is undefined`},
+		},
+		{
+			line: "main.c: In function 'main':",
+		},
+		{
+			line: "make: *** [Makefile:12: all] Error 2",
+		},
+		{
+			line: "Error: L6218E: Undefined symbol foo (referred from main.o).",
+		},
+		{
+			line: faker.Sentence(),
+		},
+	}
+	parser, err := NewParser()
+	require.NoError(t, err)
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.line, func(t *testing.T) {
+			diagnostic, ok := parser.ParseLine(test.line)
+			if test.expected == nil {
+				assert.False(t, ok)
+				assert.Nil(t, diagnostic)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, test.expected, diagnostic)
+		})
+	}
+}
+
+func TestPathMapping(t *testing.T) {
+	local := t.TempDir()
+	parser, err := NewParser(WithServiceWorkingDirectory("/workspace/project/build"), WithPathMapping("/workspace", filepath.Join(local, "other")), WithPathMapping("/workspace/project/", local))
+	require.NoError(t, err)
+	for reported, expected := range map[string]string{
+		"/workspace/project/src/main.c": filepath.Join(local, "src", "main.c"),
+		"../src/main.c":                 filepath.Join(local, "src", "main.c"),
+		"main.c":                        filepath.Join(local, "build", "main.c"),
+		"/workspace/lib/lib.c":          filepath.Join(local, "other", "lib", "lib.c"),
+		"/workspace/projects/lib.c":     filepath.Join(local, "other", "projects", "lib.c"),
+		"/usr/include/stdio.h":          "/usr/include/stdio.h",
+		`C:\sdk\include\cmsis.h`:        `C:\sdk\include\cmsis.h`,
+	} {
+		diagnostic, ok := parser.ParseLine(reported + ":1:1: error: " + faker.Sentence())
+		require.True(t, ok)
+		assert.Equal(t, expected, diagnostic.File, reported)
+		assert.Equal(t, reported, diagnostic.ReportedFile)
+	}
+
+	_, err = NewParser(WithPathMapping("", local))
+	errortest.AssertError(t, err, commonerrors.ErrUndefined)
+	_, err = NewParser(WithPathMapping("/workspace", ""))
+	errortest.AssertError(t, err, commonerrors.ErrUndefined)
+}
+
+func TestNewParserOptions(t *testing.T) {
+	directory := faker.Word()
+	options := NewParserOptions(nil, WithServiceWorkingDirectory(directory), nil)
+	assert.Equal(t, directory, options.ServiceWorkingDirectory)
+	assert.Empty(t, options.PathMappings)
+}
+
+func TestParseMessagesCollection(t *testing.T) {
+	parser, err := NewParser(WithPathMapping("/workspace", "/home/user/project"))
+	require.NoError(t, err)
+	output := strings.Join([]string{
+		"/workspace/main.c: In function 'main':",
+		"/workspace/main.c:5:7: warning: unused variable 'x' [-Wunused-variable]",
+		"    5 |   int x;",
+		"      |       ^",
+		"/workspace/main.c:6:3: error: 'y' undeclared (first use in this function)",
+	}, "\n")
+	paginator := &testPaginator{items: []any{
+		client.NewMessageObject(faker.Sentence()),
+		client.NewMessageObject(output),
+		faker.Word(),
+		nil,
+		client.NewNotificationMessageObject("/workspace/lib.c:1:1: note: " + faker.Sentence()),
+	}}
+	var diagnostics []Diagnostic
+	require.NoError(t, parser.ParseMessagesCollection(context.Background(), paginator, func(d Diagnostic) {
+		diagnostics = append(diagnostics, d)
+	}))
+	require.Len(t, diagnostics, 3)
+	assert.Equal(t, SeverityWarning, diagnostics[0].Severity)
+	assert.Equal(t, filepath.Join("/home/user/project", "main.c"), diagnostics[0].File)
+	assert.Equal(t, filepath.Join("/home/user/project", "main.c")+":5:7: warning: unused variable 'x' [-Wunused-variable]", diagnostics[0].String())
+	assert.Equal(t, SeverityError, diagnostics[1].Severity)
+	assert.Equal(t, 6, diagnostics[1].Line)
+	assert.Equal(t, SeverityNote, diagnostics[2].Severity)
+
+	err = parser.ParseMessagesCollection(context.Background(), paginator, nil)
+	errortest.AssertError(t, err, commonerrors.ErrUndefined)
+	err = parser.ParseMessagesCollection(context.Background(), nil, func(Diagnostic) {})
+	errortest.AssertError(t, err, commonerrors.ErrUndefined)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = parser.ParseMessagesCollection(ctx, &testPaginator{}, func(Diagnostic) {})
+	errortest.AssertError(t, err, commonerrors.ErrCancelled)
+}
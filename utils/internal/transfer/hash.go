@@ -0,0 +1,122 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transfer
+
+import (
+	"crypto/md5"  //nolint:gosec // only the digest size is used
+	"crypto/sha1" //nolint:gosec // only the digest size is used
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"strings"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/hashing"
+)
+
+const (
+	// HashSha512 refers to the SHA-512 hashing algorithm. Other algorithms are referred to using the references defined in golang-utils hashing package e.g. hashing.HashSha256.
+	HashSha512 = "SHA512"
+	// DefaultHashAlgorithm is the algorithm assumed for hashes whose algorithm cannot be inferred.
+	DefaultHashAlgorithm   = hashing.HashSha256
+	hashAlgorithmSeparator = ":"
+)
+
+var (
+	// SupportedHashAlgorithms lists the hashing algorithms which can be used to verify transferred files, from the weakest to the strongest.
+	SupportedHashAlgorithms = []string{hashing.HashMd5, hashing.HashSha1, hashing.HashSha256, hashing.HashBlake2256, HashSha512}
+	hashAlgorithmStrengths  = map[string]int{
+		hashing.HashMd5:       1,
+		hashing.HashSha1:      2,
+		hashing.HashSha256:    3,
+		hashing.HashBlake2256: 3,
+		HashSha512:            4,
+	}
+	// digest lengths used to infer the algorithm of hashes which are not prefixed. As SHA-256 and BLAKE2b-256 digests have the same length, a 64-character digest is considered to be SHA-256.
+	hashAlgorithmsByDigestLength = map[int]string{
+		md5.Size * 2:    hashing.HashMd5,
+		sha1.Size * 2:   hashing.HashSha1,
+		sha256.Size * 2: hashing.HashSha256,
+		sha512.Size * 2: HashSha512,
+	}
+)
+
+// Hash is a hash provided by the service e.g. `sha256:1a2b…` or `1a2b…`.
+type Hash struct {
+	// Algorithm is the canonical reference of the hashing algorithm used e.g. hashing.HashSha256.
+	Algorithm string
+	// Digest is the lower-case hexadecimal digest.
+	Digest string
+}
+
+// Matches states whether a hex digest corresponds to this hash, irrespective of the case.
+func (h *Hash) Matches(digest string) bool {
+	return strings.EqualFold(h.Digest, digest)
+}
+
+// DetermineHashAlgorithm returns the canonical reference of a supported hashing algorithm e.g. `sha-256` -> hashing.HashSha256.
+func DetermineHashAlgorithm(name string) (algorithm string, err error) {
+	n := strings.TrimSpace(strings.NewReplacer("-", "", "_", "").Replace(name))
+	if strings.EqualFold(n, HashSha512) {
+		algorithm = HashSha512
+		return
+	}
+	algorithm, err = hashing.DetermineHashingAlgorithmCanonicalReference(n)
+	if err == nil {
+		if _, supported := hashAlgorithmStrengths[algorithm]; supported {
+			return
+		}
+	}
+	algorithm = ""
+	err = commonerrors.Newf(commonerrors.ErrUnsupported, "hashing algorithm [%v] is not supported; only %v are", name, SupportedHashAlgorithms)
+	return
+}
+
+// HashAlgorithmStrength returns how strong a supported hashing algorithm is compared to others: the higher, the stronger. It returns 0 for algorithms which are not supported.
+func HashAlgorithmStrength(algorithm string) int {
+	return hashAlgorithmStrengths[algorithm]
+}
+
+// ParseHash parses a hash which may be prefixed with the algorithm used e.g. `sha256:1a2b…`.
+// If there is no prefix, the algorithm is inferred from the length of the digest: 64-character digests are considered to be SHA-256 so BLAKE2b-256 hashes must be prefixed e.g. `blake2b256:1a2b…`.
+func ParseHash(rawHash string) (h Hash, err error) {
+	rawHash = strings.TrimSpace(rawHash)
+	algorithm, digest, prefixed := strings.Cut(rawHash, hashAlgorithmSeparator)
+	if prefixed {
+		h.Algorithm, err = DetermineHashAlgorithm(algorithm)
+		if err != nil {
+			return
+		}
+	} else {
+		digest = rawHash
+		var found bool
+		h.Algorithm, found = hashAlgorithmsByDigestLength[len(digest)]
+		if !found {
+			err = commonerrors.Newf(commonerrors.ErrInvalid, "the hashing algorithm of hash [%v] could not be determined", rawHash)
+			return
+		}
+	}
+	if _, decodingErr := hex.DecodeString(digest); decodingErr != nil || digest == "" {
+		err = commonerrors.Newf(commonerrors.ErrInvalid, "hash [%v] is not a valid hexadecimal digest", rawHash)
+		return
+	}
+	h.Digest = strings.ToLower(digest)
+	return
+}
+
+// NewHashingAlgorithm returns the implementation of a supported hashing algorithm. Only SHA-512 is not provided by the golang-utils hashing package.
+func NewHashingAlgorithm(algorithm string) (h hashing.IHash, err error) {
+	if _, supported := hashAlgorithmStrengths[algorithm]; !supported {
+		err = commonerrors.Newf(commonerrors.ErrUnsupported, "hashing algorithm [%v] is not supported; only %v are", algorithm, SupportedHashAlgorithms)
+		return
+	}
+	if algorithm == HashSha512 {
+		h, err = hashing.NewBespokeHashingAlgorithm(sha512.New())
+		return
+	}
+	h, err = hashing.NewHashingAlgorithm(algorithm)
+	return
+}
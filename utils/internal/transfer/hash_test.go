@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package transfer
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/hashing"
+)
+
+func TestParseHash(t *testing.T) {
+	sha256Digest := strings.Repeat("ab", 32)
+	sha512Digest := strings.Repeat("cd", 64)
+	tests := []struct {
+		hash              string
+		expectedAlgorithm string
+		expectedDigest    string
+		expectedErr       error
+	}{
+		{hash: sha256Digest, expectedAlgorithm: hashing.HashSha256, expectedDigest: sha256Digest},
+		{hash: strings.ToUpper(sha256Digest), expectedAlgorithm: hashing.HashSha256, expectedDigest: sha256Digest},
+		{hash: "sha256:" + sha256Digest, expectedAlgorithm: hashing.HashSha256, expectedDigest: sha256Digest},
+		{hash: "SHA-512:" + strings.ToUpper(sha512Digest), expectedAlgorithm: HashSha512, expectedDigest: sha512Digest},
+		{hash: sha512Digest, expectedAlgorithm: HashSha512, expectedDigest: sha512Digest},
+		{hash: "blake2b256:" + sha256Digest, expectedAlgorithm: hashing.HashBlake2256, expectedDigest: sha256Digest},
+		{hash: strings.Repeat("a", 32), expectedAlgorithm: hashing.HashMd5, expectedDigest: strings.Repeat("a", 32)},
+		{hash: "sha1:" + strings.Repeat("0", 40), expectedAlgorithm: hashing.HashSha1, expectedDigest: strings.Repeat("0", 40)},
+		{hash: "murmur:" + sha256Digest, expectedErr: commonerrors.ErrUnsupported},
+		{hash: "whirlpool:" + sha256Digest, expectedErr: commonerrors.ErrUnsupported},
+		{hash: "sha256:", expectedErr: commonerrors.ErrInvalid},
+		{hash: "sha256:" + strings.Repeat("zz", 32), expectedErr: commonerrors.ErrInvalid},
+		{hash: "abc", expectedErr: commonerrors.ErrInvalid},
+		{hash: "", expectedErr: commonerrors.ErrInvalid},
+	}
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.hash, func(t *testing.T) {
+			h, err := ParseHash(test.hash)
+			if test.expectedErr != nil {
+				errortest.AssertError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedAlgorithm, h.Algorithm)
+			assert.Equal(t, test.expectedDigest, h.Digest)
+			assert.True(t, h.Matches(strings.ToUpper(test.expectedDigest)))
+		})
+	}
+}
+
+func TestNewHashingAlgorithm(t *testing.T) {
+	for i := range SupportedHashAlgorithms {
+		h, err := NewHashingAlgorithm(SupportedHashAlgorithms[i])
+		require.NoError(t, err)
+		assert.NotNil(t, h)
+	}
+	h, err := NewHashingAlgorithm(HashSha512)
+	require.NoError(t, err)
+	actual, err := h.Calculate(strings.NewReader("test"))
+	require.NoError(t, err)
+	expected := sha512.Sum512([]byte("test"))
+	assert.Equal(t, hex.EncodeToString(expected[:]), actual)
+
+	_, err = NewHashingAlgorithm(hashing.HashMurmur)
+	errortest.AssertError(t, err, commonerrors.ErrUnsupported)
+}
+
+func TestHashAlgorithmStrength(t *testing.T) {
+	for i := 1; i < len(SupportedHashAlgorithms); i++ {
+		assert.GreaterOrEqual(t, HashAlgorithmStrength(SupportedHashAlgorithms[i]), HashAlgorithmStrength(SupportedHashAlgorithms[i-1]))
+	}
+	assert.Zero(t, HashAlgorithmStrength(hashing.HashMurmur))
+}
@@ -0,0 +1,20 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transfer
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// IsWithinDirectory states whether target is root or a path underneath it. Paths are compared as they are: symbolic links are not resolved.
+func IsWithinDirectory(root, target string) bool {
+	rel, err := filepath.Rel(filepath.Clean(root), filepath.Clean(target))
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
@@ -0,0 +1,24 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package transfer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWithinDirectory(t *testing.T) {
+	root := filepath.Join(t.TempDir(), faker.Word())
+	assert.True(t, IsWithinDirectory(root, root))
+	assert.True(t, IsWithinDirectory(root, filepath.Join(root, faker.Word(), faker.Word())))
+	assert.True(t, IsWithinDirectory(root, filepath.Join(root, "..", filepath.Base(root), faker.Word())))
+	assert.False(t, IsWithinDirectory(root, filepath.Dir(root)))
+	assert.False(t, IsWithinDirectory(root, filepath.Join(root, "..", faker.Word())))
+	assert.False(t, IsWithinDirectory(root, root+"_"+faker.Word()))
+	assert.False(t, IsWithinDirectory(root, faker.Word()))
+}
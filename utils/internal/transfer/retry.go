@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package transfer provides helpers shared by the utilities sending files to or fetching files from the services.
+package transfer
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/logs"
+	"github.com/ARM-software/golang-utils/utils/retry"
+)
+
+// IsTransientError states whether an error may not happen again if the same request is performed later e.g. because the service was temporarily unavailable.
+// Failures to read or write local files, as well as any other unexpected error, are not considered transient.
+func IsTransientError(err error) bool {
+	return commonerrors.Any(err, commonerrors.ErrUnavailable, commonerrors.ErrTimeout)
+}
+
+// RetryIf performs fn according to a retry policy for as long as retryConditionFn states its failure may not happen again. fn is only performed once if the policy does not allow any retry.
+// Retries are reported to logger, if provided.
+func RetryIf(ctx context.Context, policy *retry.RetryPolicyConfiguration, logger logs.Loggers, fn func() error, msgOnRetry string, retryConditionFn func(err error) bool) error {
+	if policy == nil || !policy.Enabled || policy.RetryMax <= 1 {
+		return fn()
+	}
+	retryLogger := logr.Discard()
+	if logger != nil {
+		retryLogger = logs.NewPlainLogrLoggerFromLoggers(logger)
+	}
+	return retry.RetryIf(ctx, retryLogger, policy, fn, msgOnRetry, retryConditionFn)
+}
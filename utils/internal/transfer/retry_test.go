@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package transfer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/logs"
+	"github.com/ARM-software/golang-utils/utils/retry"
+)
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, IsTransientError(commonerrors.ErrUnavailable))
+	assert.True(t, IsTransientError(commonerrors.WrapError(commonerrors.ErrTimeout, commonerrors.ErrUnexpected, faker.Sentence())))
+	assert.False(t, IsTransientError(commonerrors.ErrUnexpected))
+	assert.False(t, IsTransientError(commonerrors.ErrForbidden))
+	assert.False(t, IsTransientError(nil))
+}
+
+func TestRetryIf(t *testing.T) {
+	policy := retry.DefaultBasicRetryPolicyConfiguration()
+	policy.RetryMax = 3
+	logger, err := logs.NewStringLogger(faker.Word())
+	require.NoError(t, err)
+	tests := []struct {
+		name     string
+		policy   *retry.RetryPolicyConfiguration
+		err      error
+		attempts int
+	}{
+		{
+			name:     "transient",
+			policy:   policy,
+			err:      commonerrors.ErrUnavailable,
+			attempts: 3,
+		},
+		{
+			name:     "permanent",
+			policy:   policy,
+			err:      commonerrors.ErrUnexpected,
+			attempts: 1,
+		},
+		{
+			name:     "no policy",
+			err:      commonerrors.ErrUnavailable,
+			attempts: 1,
+		},
+		{
+			name:     "no retry",
+			policy:   retry.DefaultNoRetryPolicyConfiguration(),
+			err:      commonerrors.ErrUnavailable,
+			attempts: 1,
+		},
+	}
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			err := RetryIf(context.Background(), test.policy, logger, func() error {
+				attempts++
+				return test.err
+			}, faker.Sentence(), IsTransientError)
+			errortest.AssertError(t, err, test.err)
+			assert.Equal(t, test.attempts, attempts)
+		})
+	}
+}
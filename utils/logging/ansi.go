@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package logging
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ARM-software/golang-utils/utils/logs"
+)
+
+const ansiEscape = "\x1b"
+
+// ansiEscapeSequence matches ANSI control sequences e.g. `\x1b[1;31m` used for colouring terminal output.
+var ansiEscapeSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// StripANSIEscapeSequences removes any ANSI control sequence e.g. colour codes, from a string.
+func StripANSIEscapeSequences(s string) string {
+	if !strings.Contains(s, ansiEscape) {
+		return s
+	}
+	return ansiEscapeSequence.ReplaceAllString(s, "")
+}
+
+// ansiStrippingLogger removes ANSI control sequences from what is logged before passing it to a logger which does not output to a terminal e.g. a file logger.
+type ansiStrippingLogger struct {
+	logs.Loggers
+}
+
+func newANSIStrippingLogger(l logs.Loggers) logs.Loggers {
+	return &ansiStrippingLogger{Loggers: l}
+}
+
+func (l *ansiStrippingLogger) Log(output ...interface{}) {
+	l.Loggers.Log(stripANSIEscapeSequences(output)...)
+}
+
+func (l *ansiStrippingLogger) LogError(err ...interface{}) {
+	l.Loggers.LogError(stripANSIEscapeSequences(err)...)
+}
+
+// stripANSIEscapeSequences removes ANSI control sequences from any element to log. Elements which are not strings are only converted to strings if they contain some.
+func stripANSIEscapeSequences(elements []interface{}) []interface{} {
+	stripped := make([]interface{}, len(elements))
+	for i := range elements {
+		switch e := elements[i].(type) {
+		case string:
+			stripped[i] = StripANSIEscapeSequences(e)
+		case nil:
+			stripped[i] = e
+		default:
+			if s := fmt.Sprint(e); strings.Contains(s, ansiEscape) {
+				stripped[i] = StripANSIEscapeSequences(s)
+			} else {
+				stripped[i] = e
+			}
+		}
+	}
+	return stripped
+}
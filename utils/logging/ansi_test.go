@@ -0,0 +1,43 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package logging
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+)
+
+func TestStripANSIEscapeSequences(t *testing.T) {
+	text := faker.Sentence()
+	assert.Equal(t, text, StripANSIEscapeSequences(text))
+	assert.Equal(t, "[source] error : "+text, StripANSIEscapeSequences("\x1b[36m[source]\x1b[0m \x1b[1;31merror\x1b[0m : "+text))
+	assert.Equal(t, text, StripANSIEscapeSequences("\x1b[2K\x1b[?25l"+text+"\x1b[m"))
+	assert.Empty(t, StripANSIEscapeSequences(""))
+
+	elements := stripANSIEscapeSequences([]interface{}{"\x1b[31m" + text + "\x1b[0m", 123, nil, errors.New("\x1b[33m" + text)})
+	assert.Equal(t, []interface{}{text, 123, nil, text}, elements)
+}
+
+func TestStandardClientLoggerWithColours(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "test.log")
+	logger, err := NewStandardClientLogger("test client Logger", &logPath)
+	require.NoError(t, err)
+	text := faker.Sentence()
+	logger.Log("\x1b[36m[source]\x1b[0m \x1b[1;31merror\x1b[0m : " + text)
+	logger.LogError(errors.New("\x1b[1;31m" + text + "\x1b[0m"))
+	require.NoError(t, logger.Close())
+
+	content, err := filesystem.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "[source] error : "+text)
+	assert.NotContains(t, string(content), "\x1b")
+}
@@ -147,6 +147,7 @@ func NewClientLogger(loggerSource string, defaultLoggers ...logs.Loggers) (l ILo
 }
 
 // NewStandardClientLogger returns a typical client logger with logs written to a file if the logFilePath is set.
+// ANSI control sequences e.g. colours, are removed from logs written to the file.
 func NewStandardClientLogger(loggerSource string, logFilePath *string) (l ILogger, err error) {
 	l, err = NewClientLogger(loggerSource)
 	if err != nil || reflection.IsEmpty(logFilePath) {
@@ -156,6 +157,6 @@ func NewStandardClientLogger(loggerSource string, logFilePath *string) (l ILogge
 	if err != nil {
 		return
 	}
-	err = l.Append(fileLogger)
+	err = l.Append(newANSIStrippingLogger(fileLogger))
 	return
 }
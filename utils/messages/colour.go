@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package messages
+
+import (
+	"os"
+)
+
+const (
+	noColourEnvVar = "NO_COLOR"
+	ansiReset      = "\x1b[0m"
+	ansiFaint      = "\x1b[2m"
+	ansiBoldRed    = "\x1b[1;31m"
+	ansiGreen      = "\x1b[32m"
+	ansiYellow     = "\x1b[33m"
+	ansiCyan       = "\x1b[36m"
+	sourceColour   = ansiCyan
+	noColour       = ""
+)
+
+// isColourTerminal states whether output can be coloured i.e. the standard output is a terminal and colours were not disabled by the user.
+// It is a variable so that tests can simulate terminals.
+var isColourTerminal = func() bool {
+	if coloursDisabledByUser() {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// coloursDisabledByUser states whether the user disabled colours by setting the `NO_COLOR` environment variable to a non-empty value (see https://no-color.org).
+func coloursDisabledByUser() bool {
+	return os.Getenv(noColourEnvVar) != ""
+}
+
+// severityColour returns the ANSI escape code corresponding to a severity. Severities which are not part of the services' vocabulary are not coloured.
+func severityColour(severity string) string {
+	s, ok := ParseSeverity(severity)
+	if !ok {
+		return noColour
+	}
+	switch s {
+	case SeverityError:
+		return ansiBoldRed
+	case SeverityWarning:
+		return ansiYellow
+	case SeverityInfo:
+		return ansiGreen
+	default:
+		return ansiFaint
+	}
+}
+
+func colourise(colour, s string) string {
+	if colour == noColour || s == "" {
+		return s
+	}
+	return colour + s + ansiReset
+}
+
+func (f *MessageFormatter) colourSource(source string) string {
+	if !f.options.colour {
+		return source
+	}
+	return colourise(sourceColour, source)
+}
+
+func (f *MessageFormatter) colourSeverity(severity string) string {
+	if !f.options.colour {
+		return severity
+	}
+	return colourise(severityColour(severity), severity)
+}
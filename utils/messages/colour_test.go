@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package messages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/logging"
+)
+
+func TestColouredMessages(t *testing.T) {
+	text := faker.Sentence()
+	newMessage := func(severity string) IMessage {
+		return newTestMessage(text, "worker", severity, time.Now())
+	}
+
+	t.Run("coloured", func(t *testing.T) {
+		formatter := NewMessageFormatter(WithSource, WithSeverity, WithColour)
+		tests := map[string]string{
+			"ERROR":   "\x1b[36m[worker]\x1b[0m \x1b[1;31mERROR\x1b[0m : " + text,
+			"warning": "\x1b[36m[worker]\x1b[0m \x1b[33mwarning\x1b[0m : " + text,
+			"Info":    "\x1b[36m[worker]\x1b[0m \x1b[32mInfo\x1b[0m : " + text,
+			"debug":   "\x1b[36m[worker]\x1b[0m \x1b[2mdebug\x1b[0m : " + text,
+			"MAJOR":   "\x1b[36m[worker]\x1b[0m MAJOR : " + text,
+		}
+		for severity, expected := range tests {
+			formatted, err := formatter.FormatMessage(newMessage(severity))
+			require.NoError(t, err)
+			assert.Equal(t, expected, formatted)
+			assert.Equal(t, "[worker] "+severity+" : "+text, logging.StripANSIEscapeSequences(formatted))
+		}
+	})
+	t.Run("not coloured", func(t *testing.T) {
+		formatted, err := NewMessageFormatter(WithSource, WithSeverity).FormatMessage(newMessage("error"))
+		require.NoError(t, err)
+		assert.Equal(t, "[worker] error : "+text, formatted)
+		formatted, err = NewJSONMessageFormatter(WithColour).FormatMessage(newMessage("error"))
+		require.NoError(t, err)
+		assert.NotContains(t, formatted, "\x1b")
+	})
+	t.Run("terminal detection", func(t *testing.T) {
+		previous := isColourTerminal
+		defer func() { isColourTerminal = previous }()
+		isColourTerminal = func() bool { return false }
+		formatted, err := NewMessageFormatter(WithSeverity, WithTerminalColour).FormatMessage(newMessage("error"))
+		require.NoError(t, err)
+		assert.NotContains(t, formatted, "\x1b")
+		isColourTerminal = func() bool { return true }
+		formatted, err = NewMessageFormatter(WithSeverity, WithTerminalColour).FormatMessage(newMessage("error"))
+		require.NoError(t, err)
+		assert.Contains(t, formatted, "\x1b[1;31merror")
+	})
+	t.Run("NO_COLOR", func(t *testing.T) {
+		t.Setenv(noColourEnvVar, "1")
+		assert.True(t, coloursDisabledByUser())
+		assert.False(t, isColourTerminal())
+		// an empty value does not disable colours.
+		t.Setenv(noColourEnvVar, "")
+		assert.False(t, coloursDisabledByUser())
+	})
+}
@@ -7,8 +7,11 @@
 package messages
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ARM-software/golang-utils/utils/commonerrors"
 	"github.com/ARM-software/golang-utils/utils/field"
@@ -18,6 +21,10 @@ type FormatterOptions struct {
 	source    bool
 	timestamp bool
 	severity  bool
+	json      bool
+	jobName   string
+	jobType   string
+	colour    bool
 }
 
 type FormatterOption func(*FormatterOptions) *FormatterOptions
@@ -49,8 +56,71 @@ var WithSeverity FormatterOption = func(o *FormatterOptions) *FormatterOptions {
 	return o
 }
 
+// WithJSON formats messages as JSON objects e.g. for log pipelines expecting structured records, rather than as human-readable strings.
+// Each message results in a single line object holding its `source`, `ctime` (RFC 3339), `severity` and `message` as well as the job name and type if specified (see WithJobName and WithJobType).
+// Fields are included whenever they are known, regardless of other formatting options.
+var WithJSON FormatterOption = func(o *FormatterOptions) *FormatterOptions {
+	if o == nil {
+		return o
+	}
+	o.json = true
+	return o
+}
+
+// WithJobName specifies the name of the job messages relate to, so that it is included in JSON records (see WithJSON).
+func WithJobName(name string) FormatterOption {
+	return func(o *FormatterOptions) *FormatterOptions {
+		if o == nil {
+			return o
+		}
+		o.jobName = name
+		return o
+	}
+}
+
+// WithJobType specifies the type of the job messages relate to e.g. `build`, so that it is included in JSON records (see WithJSON).
+func WithJobType(jobType string) FormatterOption {
+	return func(o *FormatterOptions) *FormatterOptions {
+		if o == nil {
+			return o
+		}
+		o.jobType = jobType
+		return o
+	}
+}
+
+// WithColour colours the severity and the source of messages using ANSI escape codes e.g. errors in red and warnings in yellow, so that they stand out in terminals.
+// Colours only apply to human-readable strings and are removed from logs written to files by loggers created using logging.NewStandardClientLogger.
+var WithColour FormatterOption = func(o *FormatterOptions) *FormatterOptions {
+	if o == nil {
+		return o
+	}
+	o.colour = true
+	return o
+}
+
+// WithTerminalColour colours messages similarly to WithColour but only if the standard output is a terminal and colours were not disabled by setting the `NO_COLOR` environment variable to a non-empty value (see https://no-color.org).
+var WithTerminalColour FormatterOption = func(o *FormatterOptions) *FormatterOptions {
+	if o == nil {
+		return o
+	}
+	o.colour = isColourTerminal()
+	return o
+}
+
+// jsonMessage describes the record a message is formatted into when formatting messages as JSON.
+type jsonMessage struct {
+	Source   string `json:"source,omitempty"`
+	Ctime    string `json:"ctime,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Message  string `json:"message"`
+	JobName  string `json:"job_name,omitempty"`
+	JobType  string `json:"job_type,omitempty"`
+}
+
 type MessageFormatter struct {
-	options FormatterOptions
+	options  FormatterOptions
+	template *messageTemplate
 }
 
 func (f *MessageFormatter) FormatMessage(msg IMessage) (s string, err error) {
@@ -58,10 +128,18 @@ func (f *MessageFormatter) FormatMessage(msg IMessage) (s string, err error) {
 		err = commonerrors.UndefinedVariable("message")
 		return
 	}
+	if f.template != nil {
+		s, err = f.template.format(msg, &f.options)
+		return
+	}
+	if f.options.json {
+		s, err = f.formatJSONMessage(msg)
+		return
+	}
 	var b strings.Builder
 	if f.options.source {
 		if source, ok := msg.GetSourceOk(); ok {
-			_, err = b.WriteString(fmt.Sprintf("[%s] ", field.OptionalString(source, "")))
+			_, err = b.WriteString(fmt.Sprintf("%s ", f.colourSource(fmt.Sprintf("[%s]", field.OptionalString(source, "")))))
 			if err != nil {
 				err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "")
 				return
@@ -79,7 +157,7 @@ func (f *MessageFormatter) FormatMessage(msg IMessage) (s string, err error) {
 	}
 	if f.options.severity {
 		if severity, ok := msg.GetSeverityOk(); ok {
-			_, err = b.WriteString(fmt.Sprintf("%s ", field.OptionalString(severity, "")))
+			_, err = b.WriteString(fmt.Sprintf("%s ", f.colourSeverity(field.OptionalString(severity, ""))))
 			if err != nil {
 				err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "")
 				return
@@ -106,6 +184,36 @@ func (f *MessageFormatter) FormatMessage(msg IMessage) (s string, err error) {
 	return
 }
 
+func (f *MessageFormatter) formatJSONMessage(msg IMessage) (s string, err error) {
+	record := jsonMessage{
+		JobName: f.options.jobName,
+		JobType: f.options.jobType,
+	}
+	if source, ok := msg.GetSourceOk(); ok {
+		record.Source = field.OptionalString(source, "")
+	}
+	if ctime, ok := msg.GetCtimeOk(); ok && ctime != nil {
+		record.Ctime = ctime.Format(time.RFC3339Nano)
+	}
+	if severity, ok := msg.GetSeverityOk(); ok {
+		record.Severity = field.OptionalString(severity, "")
+	}
+	if message, ok := msg.GetMessageOk(); ok {
+		record.Message = field.OptionalString(message, "")
+	}
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	// messages are not meant to be embedded in HTML and so, characters such as `<` are kept as they are.
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(&record)
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrMarshalling, err, "could not format message as JSON")
+		return
+	}
+	s = strings.TrimSuffix(b.String(), "\n")
+	return
+}
+
 // NewMessageFormatter creates a formatter for messages
 func NewMessageFormatter(option ...FormatterOption) *MessageFormatter {
 	options := &FormatterOptions{}
@@ -120,6 +228,11 @@ func DefaultMessageFormatter() *MessageFormatter {
 	return NewMessageFormatter(WithSource, WithTimeStamp, WithSeverity)
 }
 
+// NewJSONMessageFormatter returns a formatter which formats messages as JSON objects (see WithJSON) e.g. for use with NewMessageLoggerFactoryWithFormatter.
+func NewJSONMessageFormatter(option ...FormatterOption) *MessageFormatter {
+	return NewMessageFormatter(append([]FormatterOption{WithJSON}, option...)...)
+}
+
 // FormatMessageWithOptions formats a job message with formatting options.
 func FormatMessageWithOptions(msg IMessage, option ...FormatterOption) (s string, err error) {
 	return NewMessageFormatter(option...).FormatMessage(msg)
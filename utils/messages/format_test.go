@@ -5,7 +5,9 @@
 package messages
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -69,3 +71,50 @@ func TestFormatMessage(t *testing.T) {
 		fmt.Println(message)
 	})
 }
+
+func TestFormatMessageAsJSON(t *testing.T) {
+	ctime := time.Date(2025, time.March, 4, 10, 11, 12, 0, time.UTC)
+	source := faker.Name()
+	text := fmt.Sprintf("%v <%v> \"%v\"", faker.Sentence(), faker.Word(), faker.Word())
+	message := client.NewMessageObject(text)
+	message.SetSource(source)
+	message.SetCtime(ctime)
+	message.SetSeverity("MAJOR")
+
+	t.Run("all fields", func(t *testing.T) {
+		jobName := faker.Word()
+		formatted, err := NewJSONMessageFormatter(WithJobName(jobName), WithJobType("build")).FormatMessage(message)
+		require.NoError(t, err)
+		assert.False(t, strings.Contains(formatted, "\n"))
+		assert.Contains(t, formatted, "<")
+		var record map[string]string
+		require.NoError(t, json.Unmarshal([]byte(formatted), &record))
+		assert.Equal(t, map[string]string{
+			"source":   source,
+			"ctime":    "2025-03-04T10:11:12Z",
+			"severity": "MAJOR",
+			"message":  text,
+			"job_name": jobName,
+			"job_type": "build",
+		}, record)
+	})
+	t.Run("unknown fields", func(t *testing.T) {
+		formatted, err := NewJSONMessageFormatter().FormatMessage(client.NewMessageObject(text))
+		require.NoError(t, err)
+		var record map[string]string
+		require.NoError(t, json.Unmarshal([]byte(formatted), &record))
+		assert.Equal(t, map[string]string{"message": text}, record)
+	})
+	t.Run("with other options", func(t *testing.T) {
+		formatted, err := FormatMessageWithOptions(message, WithSeverity, WithJSON)
+		require.NoError(t, err)
+		var record map[string]string
+		require.NoError(t, json.Unmarshal([]byte(formatted), &record))
+		assert.Equal(t, source, record["source"])
+	})
+	t.Run("undefined message", func(t *testing.T) {
+		formatted, err := NewJSONMessageFormatter().FormatMessage(nil)
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+		assert.Empty(t, formatted)
+	})
+}
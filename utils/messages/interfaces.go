@@ -16,7 +16,7 @@ import (
 
 // Mocks are generated using `go generate ./...`
 // Add interfaces to the following command for a mock to be generated
-//go:generate go tool mockgen -destination=../mocks/mock_$GOPACKAGE.go -package=mocks github.com/ARM-software/embedded-development-services-client-utils/utils/$GOPACKAGE IMessage,IMessageLogger
+//go:generate go tool mockgen -destination=../mocks/mock_$GOPACKAGE.go -package=mocks github.com/ARM-software/embedded-development-services-client-utils/utils/$GOPACKAGE IMessage,IMessageLogger,IMessageLoggerWithStatistics
 
 const (
 	// DefaultMessagesPrintingFrequency describes the default frequency at which messages are printed
@@ -27,6 +27,8 @@ const (
 
 	// DefaultStreamExhaustionGracePeriod describes the grace period which should happen when expecting message stream exhaustion.
 	DefaultStreamExhaustionGracePeriod = time.Second
+	// DefaultMessageBufferSize describes the default number of messages asynchronous message loggers can hold before they are printed.
+	DefaultMessageBufferSize = 1000
 )
 
 // IMessage defines a generic service message.
@@ -49,3 +51,20 @@ type IMessageLogger interface {
 	LogMarshallingError(rawMessage *any)
 	LogMessagesCollection(ctx context.Context, messagePaginator pagination.IGenericPaginator) error
 }
+
+// IMessageLoggerWithStatistics defines a message logger which keeps track of what happened to the messages it was given. Message loggers created by this package implement it.
+type IMessageLoggerWithStatistics interface {
+	IMessageLogger
+	// GetStatistics returns how many messages were not printed or had to wait before being logged.
+	GetStatistics() MessageStatistics
+}
+
+// MessageStatistics describes what happened to the messages logged by a message logger.
+type MessageStatistics struct {
+	// Suppressed is the number of messages which were not printed because of their severity (see WithMinimumSeverity).
+	Suppressed int64
+	// Dropped is the number of messages which were lost because they could not be printed fast enough (see WithLosslessPrinting).
+	Dropped int64
+	// Delayed is the number of messages which had to wait for some space in the buffer before being logged, when printing is lossless (see WithLosslessPrinting).
+	Delayed int64
+}
@@ -17,28 +17,91 @@ import (
 	"github.com/ARM-software/golang-utils/utils/reflection"
 )
 
-const (
-	messageBufferSize = 1000
-)
+type MessageLoggerOptions struct {
+	minimumSeverity     *Severity
+	summariseSuppressed bool
+	bufferSize          int
+	lossless            bool
+}
+
+type MessageLoggerOption func(*MessageLoggerOptions)
+
+// WithMinimumSeverity specifies that messages less severe than minimum should not be printed e.g. debug messages.
+// Messages whose severity is missing or not part of the services' vocabulary (see ParseSeverity) are always printed.
+func WithMinimumSeverity(minimum Severity) MessageLoggerOption {
+	return func(o *MessageLoggerOptions) {
+		o.minimumSeverity = &minimum
+	}
+}
+
+// WithBufferSize specifies how many messages asynchronous loggers can hold before they are printed. By default, DefaultMessageBufferSize messages are held.
+// When the buffer is full, messages are dropped unless printing is lossless (see WithLosslessPrinting).
+func WithBufferSize(size int) MessageLoggerOption {
+	return func(o *MessageLoggerOptions) {
+		o.bufferSize = size
+	}
+}
+
+// WithLosslessPrinting specifies whether asynchronous loggers should never drop messages. If so, logging a message waits for some space in the buffer when it is full (see WithBufferSize), slowing down whatever is fetching messages e.g. a paginator, rather than dropping it.
+// Otherwise, messages are dropped when the buffer is full so that logging never blocks.
+func WithLosslessPrinting(lossless bool) MessageLoggerOption {
+	return func(o *MessageLoggerOptions) {
+		o.lossless = lossless
+	}
+}
+
+// WithSuppressedMessagesSummary specifies whether a summary of the number of messages which were not printed because of their severity (see WithMinimumSeverity) should be logged when the logger is closed.
+func WithSuppressedMessagesSummary(summarise bool) MessageLoggerOption {
+	return func(o *MessageLoggerOptions) {
+		o.summariseSuppressed = summarise
+	}
+}
+
+func newMessageLoggerOptions(opts ...MessageLoggerOption) *MessageLoggerOptions {
+	options := &MessageLoggerOptions{
+		bufferSize: DefaultMessageBufferSize,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
 
 type logger struct {
 	rawLogger    logging.ILogger
 	printer      logs.WriterWithSource
 	msgFormatter MessageFormatter
+	filter       *severityFilter
+	summarise    bool
+	statistics   *printingStatistics
 }
 
-func newLogger(rawLogger logging.ILogger, printer logs.WriterWithSource, msgFormatter *MessageFormatter) (IMessageLogger, error) {
+func newLogger(rawLogger logging.ILogger, printer logs.WriterWithSource, msgFormatter *MessageFormatter, statistics *printingStatistics, options *MessageLoggerOptions) (IMessageLogger, error) {
 	if rawLogger == nil {
 		return nil, commonerrors.ErrNoLogger
 	}
 	if msgFormatter == nil {
 		return nil, commonerrors.UndefinedVariable("message formatter")
 	}
-	return &logger{
+	if options == nil {
+		options = newMessageLoggerOptions()
+	}
+	if statistics == nil {
+		statistics = &printingStatistics{}
+	}
+	l := &logger{
 		rawLogger:    rawLogger,
 		printer:      printer,
 		msgFormatter: *msgFormatter,
-	}, nil
+		statistics:   statistics,
+	}
+	if options.minimumSeverity != nil {
+		l.filter = newSeverityFilter(*options.minimumSeverity)
+		l.summarise = options.summariseSuppressed
+	}
+	return l, nil
 }
 
 func (l *logger) Check() error {
@@ -61,8 +124,26 @@ func (l *logger) LogError(err ...interface{}) {
 	l.rawLogger.LogError(err...)
 }
 
-func (l *logger) Close() error {
-	return l.printer.Close()
+func (l *logger) Close() (err error) {
+	err = l.printer.Close()
+	// summaries are logged once all messages have been printed so that they come last.
+	if l.summarise {
+		if summary := l.filter.summary(); summary != "" {
+			l.rawLogger.Log(summary)
+		}
+	}
+	if summary := l.statistics.summary(); summary != "" {
+		l.rawLogger.Log(summary)
+	}
+	return
+}
+
+func (l *logger) GetStatistics() MessageStatistics {
+	return MessageStatistics{
+		Suppressed: int64(l.filter.suppressedCount()),
+		Dropped:    l.statistics.dropped.Load(),
+		Delayed:    l.statistics.delayed.Load(),
+	}
 }
 
 func (l *logger) SetSource(source string) error {
@@ -70,6 +151,9 @@ func (l *logger) SetSource(source string) error {
 }
 
 func (l *logger) LogMessage(msg IMessage) {
+	if !l.filter.keep(msg) {
+		return
+	}
 	m, err := l.msgFormatter.FormatMessage(msg)
 	if err != nil {
 		l.rawLogger.LogErrorAndMessage(err, "failed logging message")
@@ -125,11 +209,13 @@ func NewBasicAsynchronousMessageLogger(ctx context.Context, rawLogger logging.IL
 }
 
 // NewBasicAsynchronousMessageLoggerWithFormatter creates an asynchronous logger for messages which prints them as they come.
-func NewBasicAsynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, msgFormatter *MessageFormatter) (IMessageLogger, error) {
+func NewBasicAsynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, msgFormatter *MessageFormatter, opts ...MessageLoggerOption) (IMessageLogger, error) {
 	if rawLogger == nil {
 		return nil, commonerrors.ErrNoLogger
 	}
-	return newLogger(rawLogger, newBasicAsynchronousMessagePrinter(ctx, rawLogger), msgFormatter)
+	options := newMessageLoggerOptions(opts...)
+	statistics := &printingStatistics{}
+	return newLogger(rawLogger, newBasicAsynchronousMessagePrinter(ctx, rawLogger, options, statistics), msgFormatter, statistics, options)
 }
 
 // NewPeriodicAsynchronousMessageLogger creates an asynchronous logger for messages which prints them at regular intervals. It uses the default message formatter.
@@ -138,11 +224,13 @@ func NewPeriodicAsynchronousMessageLogger(ctx context.Context, rawLogger logging
 }
 
 // NewPeriodicAsynchronousMessageLoggerWithFormatter creates an asynchronous logger for messages which prints them at regular intervals.
-func NewPeriodicAsynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, printPeriod time.Duration, msgFormatter *MessageFormatter) (IMessageLogger, error) {
+func NewPeriodicAsynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, printPeriod time.Duration, msgFormatter *MessageFormatter, opts ...MessageLoggerOption) (IMessageLogger, error) {
 	if rawLogger == nil {
 		return nil, commonerrors.ErrNoLogger
 	}
-	return newLogger(rawLogger, newPeriodicAsynchronousMessagePrinter(ctx, rawLogger, printPeriod), msgFormatter)
+	options := newMessageLoggerOptions(opts...)
+	statistics := &printingStatistics{}
+	return newLogger(rawLogger, newPeriodicAsynchronousMessagePrinter(ctx, rawLogger, printPeriod, options, statistics), msgFormatter, statistics, options)
 }
 
 // NewBasicSynchronousMessageLogger creates a synchronous logger for messages which prints them as they come. It uses the default message formatter.
@@ -151,11 +239,11 @@ func NewBasicSynchronousMessageLogger(ctx context.Context, rawLogger logging.ILo
 }
 
 // NewBasicSynchronousMessageLoggerWithFormatter creates a synchronous logger for messages which prints them as they come.
-func NewBasicSynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, msgFormatter *MessageFormatter) (IMessageLogger, error) {
+func NewBasicSynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, msgFormatter *MessageFormatter, opts ...MessageLoggerOption) (IMessageLogger, error) {
 	if rawLogger == nil {
 		return nil, commonerrors.ErrNoLogger
 	}
-	return newLogger(rawLogger, newBasicSynchronousMessagePrinter(ctx, rawLogger), msgFormatter)
+	return newLogger(rawLogger, newBasicSynchronousMessagePrinter(ctx, rawLogger), msgFormatter, nil, newMessageLoggerOptions(opts...))
 }
 
 // NewPeriodicSynchronousMessageLogger creates a synchronous logger for messages which prints them at regular intervals. It uses the default message formatter.
@@ -163,15 +251,15 @@ func NewPeriodicSynchronousMessageLogger(ctx context.Context, rawLogger logging.
 	if rawLogger == nil {
 		return nil, commonerrors.ErrNoLogger
 	}
-	return newLogger(rawLogger, newPeriodicSynchronousMessagePrinter(ctx, rawLogger, printPeriod), DefaultMessageFormatter())
+	return newLogger(rawLogger, newPeriodicSynchronousMessagePrinter(ctx, rawLogger, printPeriod), DefaultMessageFormatter(), nil, nil)
 }
 
 // NewPeriodicSynchronousMessageLoggerWithFormatter creates a synchronous logger for messages which prints them at regular intervals.
-func NewPeriodicSynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, printPeriod time.Duration, msgFormatter *MessageFormatter) (IMessageLogger, error) {
+func NewPeriodicSynchronousMessageLoggerWithFormatter(ctx context.Context, rawLogger logging.ILogger, printPeriod time.Duration, msgFormatter *MessageFormatter, opts ...MessageLoggerOption) (IMessageLogger, error) {
 	if rawLogger == nil {
 		return nil, commonerrors.ErrNoLogger
 	}
-	return newLogger(rawLogger, newPeriodicSynchronousMessagePrinter(ctx, rawLogger, printPeriod), msgFormatter)
+	return newLogger(rawLogger, newPeriodicSynchronousMessagePrinter(ctx, rawLogger, printPeriod), msgFormatter, nil, newMessageLoggerOptions(opts...))
 }
 
 // MessageLoggerFactory defines a message logger factory
@@ -180,6 +268,7 @@ type MessageLoggerFactory struct {
 	period       time.Duration
 	rawLogger    logging.ILogger
 	msgFormatter *MessageFormatter
+	options      []MessageLoggerOption
 }
 
 // Create returns a message logger.
@@ -189,14 +278,31 @@ func (f *MessageLoggerFactory) Create(ctx context.Context) (IMessageLogger, erro
 	}
 	if f.asynchronous {
 		if f.period > 0 {
-			return NewPeriodicAsynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.period, f.msgFormatter)
+			return NewPeriodicAsynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.period, f.msgFormatter, f.options...)
 		}
-		return NewBasicAsynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.msgFormatter)
+		return NewBasicAsynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.msgFormatter, f.options...)
 	}
 	if f.period > 0 {
-		return NewPeriodicSynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.period, f.msgFormatter)
+		return NewPeriodicSynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.period, f.msgFormatter, f.options...)
 	}
-	return NewBasicSynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.msgFormatter)
+	return NewBasicSynchronousMessageLoggerWithFormatter(ctx, f.rawLogger, f.msgFormatter, f.options...)
+}
+
+// SetBufferSize specifies how many messages asynchronous loggers created can hold before they are printed (see WithBufferSize).
+func (f *MessageLoggerFactory) SetBufferSize(size int) *MessageLoggerFactory {
+	f.options = append(f.options, WithBufferSize(size))
+	return f
+}
+
+// GetBufferSize returns how many messages asynchronous loggers created can hold before they are printed.
+func (f *MessageLoggerFactory) GetBufferSize() int {
+	return newMessageLoggerOptions(f.options...).getBufferSize()
+}
+
+// SetLosslessPrinting specifies whether asynchronous loggers created should never drop messages (see WithLosslessPrinting).
+func (f *MessageLoggerFactory) SetLosslessPrinting(lossless bool) *MessageLoggerFactory {
+	f.options = append(f.options, WithLosslessPrinting(lossless))
+	return f
 }
 
 // NewMessageLoggerFactory returns a message logger factory.
@@ -204,16 +310,22 @@ func NewMessageLoggerFactory(logger logging.ILogger, asynchronous bool, printing
 	return NewMessageLoggerFactoryWithFormatter(logger, asynchronous, printingPeriod, DefaultMessageFormatter())
 }
 
-// NewMessageLoggerFactoryWithFormatter returns a message logger factory.
-func NewMessageLoggerFactoryWithFormatter(logger logging.ILogger, asynchronous bool, printingPeriod time.Duration, formatter *MessageFormatter) *MessageLoggerFactory {
+// NewMessageLoggerFactoryWithFormatter returns a message logger factory. Options e.g. WithMinimumSeverity apply to all the message loggers created.
+func NewMessageLoggerFactoryWithFormatter(logger logging.ILogger, asynchronous bool, printingPeriod time.Duration, formatter *MessageFormatter, opts ...MessageLoggerOption) *MessageLoggerFactory {
 	return &MessageLoggerFactory{
 		asynchronous: asynchronous,
 		period:       printingPeriod,
 		rawLogger:    logger,
 		msgFormatter: formatter,
+		options:      append([]MessageLoggerOption(nil), opts...),
 	}
 }
 
+// NewMessageLoggerFactoryWithMinimumSeverity returns a message logger factory creating loggers which do not print messages less severe than minimum (see WithMinimumSeverity) and which log a summary of the messages suppressed when closed.
+func NewMessageLoggerFactoryWithMinimumSeverity(logger logging.ILogger, asynchronous bool, printingPeriod time.Duration, minimum Severity) *MessageLoggerFactory {
+	return NewMessageLoggerFactoryWithFormatter(logger, asynchronous, printingPeriod, DefaultMessageFormatter(), WithMinimumSeverity(minimum), WithSuppressedMessagesSummary(true))
+}
+
 // NewMessageLoggerFactoryWithFormattingOptions returns a message logger factory.
 func NewMessageLoggerFactoryWithFormattingOptions(logger logging.ILogger, asynchronous bool, printingPeriod time.Duration, option ...FormatterOption) *MessageLoggerFactory {
 	return NewMessageLoggerFactoryWithFormatter(logger, asynchronous, printingPeriod, NewMessageFormatter(option...))
@@ -259,8 +371,8 @@ func (m *periodicMessagePrinter) Write(p []byte) (int, error) {
 }
 
 // newBasicAsynchronousMessagePrinter will print messages asynchronously in a disconnected manner from the paging mechanism.
-func newBasicAsynchronousMessagePrinter(ctx context.Context, logger logging.ILogger) logs.WriterWithSource {
-	return logs.NewDiodeWriterForSlowWriter(newBasicSynchronousMessagePrinter(ctx, logger), messageBufferSize, 0, logger)
+func newBasicAsynchronousMessagePrinter(ctx context.Context, logger logging.ILogger, options *MessageLoggerOptions, statistics *printingStatistics) logs.WriterWithSource {
+	return newAsynchronousMessagePrinter(ctx, newBasicSynchronousMessagePrinter(ctx, logger), options, statistics)
 }
 
 // newBasicSynchronousMessagePrinter will print messages synchronously.
@@ -272,8 +384,8 @@ func newBasicSynchronousMessagePrinter(ctx context.Context, logger logging.ILogg
 }
 
 // newPeriodicAsynchronousMessagePrinter will print messages at regular intervals asynchronously in a disconnected manner from the paging mechanism.
-func newPeriodicAsynchronousMessagePrinter(ctx context.Context, logger logging.ILogger, period time.Duration) logs.WriterWithSource {
-	return logs.NewDiodeWriterForSlowWriter(newPeriodicSynchronousMessagePrinter(ctx, logger, period), messageBufferSize, 0, logger)
+func newPeriodicAsynchronousMessagePrinter(ctx context.Context, logger logging.ILogger, period time.Duration, options *MessageLoggerOptions, statistics *printingStatistics) logs.WriterWithSource {
+	return newAsynchronousMessagePrinter(ctx, newPeriodicSynchronousMessagePrinter(ctx, logger, period), options, statistics)
 }
 
 // newPeriodicSynchronousMessagePrinter will print messages synchronously but at regular intervals.
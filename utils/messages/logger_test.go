@@ -62,6 +62,16 @@ func TestNewMessageLogger(t *testing.T) {
 				return NewMessageLoggerFactory(l, true, period).Create(ctx)
 			},
 		},
+		{
+			messageLogger: func(ctx context.Context, l logging.ILogger) (IMessageLogger, error) {
+				return NewMessageLoggerFactoryWithFormatter(l, false, 0, NewJSONMessageFormatter(WithJobName(faker.Word()))).Create(ctx)
+			},
+		},
+		{
+			messageLogger: func(ctx context.Context, l logging.ILogger) (IMessageLogger, error) {
+				return NewMessageLoggerFactory(l, true, period).SetLosslessPrinting(true).SetBufferSize(1).Create(ctx)
+			},
+		},
 	}
 	for i := range tests {
 		test := tests[i]
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package messages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/logs"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+)
+
+// printingStatistics keeps track of messages dropped or delayed by asynchronous printers.
+type printingStatistics struct {
+	dropped atomic.Int64
+	delayed atomic.Int64
+}
+
+// summary describes the messages which were dropped or delayed or returns an empty string if there were none.
+func (s *printingStatistics) summary() string {
+	var summaries []string
+	if dropped := s.dropped.Load(); dropped > 0 {
+		summaries = append(summaries, fmt.Sprintf("%d messages were dropped as they could not be printed fast enough", dropped))
+	}
+	if delayed := s.delayed.Load(); delayed > 0 {
+		summaries = append(summaries, fmt.Sprintf("%d messages were delayed as the message buffer was full", delayed))
+	}
+	return strings.Join(summaries, "; ")
+}
+
+func (o *MessageLoggerOptions) getBufferSize() int {
+	if o.bufferSize <= 0 {
+		return DefaultMessageBufferSize
+	}
+	return o.bufferSize
+}
+
+// newAsynchronousMessagePrinter returns a printer which prints messages using slowPrinter in the background, either dropping messages or waiting when its buffer is full.
+func newAsynchronousMessagePrinter(ctx context.Context, slowPrinter logs.WriterWithSource, options *MessageLoggerOptions, statistics *printingStatistics) logs.WriterWithSource {
+	return newBufferedMessagePrinter(ctx, slowPrinter, options.getBufferSize(), options.lossless, statistics)
+}
+
+// bufferedMessagePrinter prints messages in the background using a bounded buffer. When the buffer is full, messages are dropped unless printing is lossless, in which case writing waits for some space so that whatever produces messages is slowed down.
+type bufferedMessagePrinter struct {
+	ctx         context.Context
+	slowPrinter logs.WriterWithSource
+	messages    chan []byte
+	done        chan struct{}
+	lossless    bool
+	statistics  *printingStatistics
+	// mu prevents messages from being written while the printer is closing.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newBufferedMessagePrinter(ctx context.Context, slowPrinter logs.WriterWithSource, bufferSize int, lossless bool, statistics *printingStatistics) logs.WriterWithSource {
+	p := &bufferedMessagePrinter{
+		ctx:         ctx,
+		slowPrinter: slowPrinter,
+		messages:    make(chan []byte, bufferSize),
+		done:        make(chan struct{}),
+		lossless:    lossless,
+		statistics:  statistics,
+	}
+	go p.print()
+	return p
+}
+
+func (p *bufferedMessagePrinter) print() {
+	defer close(p.done)
+	for m := range p.messages {
+		// errors are ignored, as with other asynchronous printers, as there is no one to report them to.
+		_, _ = p.slowPrinter.Write(m)
+	}
+}
+
+func (p *bufferedMessagePrinter) Write(b []byte) (n int, err error) {
+	err = parallelisation.DetermineContextError(p.ctx)
+	if err != nil {
+		return
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		err = commonerrors.New(commonerrors.ErrCondition, "message printer is closed")
+		return
+	}
+	// the content is copied as callers may reuse their buffer once Write returns.
+	m := make([]byte, len(b))
+	copy(m, b)
+	select {
+	case p.messages <- m:
+	default:
+		if !p.lossless {
+			// dropped messages are only counted here and reported once the printer is closed (see printingStatistics.summary).
+			p.statistics.dropped.Add(1)
+			n = len(b)
+			return
+		}
+		p.statistics.delayed.Add(1)
+		select {
+		case p.messages <- m:
+		case <-p.ctx.Done():
+			err = parallelisation.DetermineContextError(p.ctx)
+			return
+		}
+	}
+	n = len(b)
+	return
+}
+
+// Close waits for all the messages written to be printed before closing the underlying printer.
+func (p *bufferedMessagePrinter) Close() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.messages)
+	}
+	p.mu.Unlock()
+	<-p.done
+	return p.slowPrinter.Close()
+}
+
+func (p *bufferedMessagePrinter) SetSource(source string) error {
+	return p.slowPrinter.SetSource(source)
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package messages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/logging"
+	"github.com/ARM-software/embedded-development-services-client/client"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/logs"
+)
+
+// slowLogger takes some time to log anything.
+type slowLogger struct {
+	*logs.StringLoggers
+}
+
+func (l *slowLogger) Log(output ...interface{}) {
+	time.Sleep(time.Millisecond)
+	l.StringLoggers.Log(output...)
+}
+
+// getStatistics returns the statistics of a message logger created by this package.
+func getStatistics(t *testing.T, logger IMessageLogger) MessageStatistics {
+	t.Helper()
+	loggerWithStatistics, ok := logger.(IMessageLoggerWithStatistics)
+	require.True(t, ok)
+	return loggerWithStatistics.GetStatistics()
+}
+
+func TestLosslessPrinting(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	stringLogger, err := logs.NewStringLogger(faker.Word())
+	require.NoError(t, err)
+	rawLogger, err := logging.NewClientLogger(faker.Word(), &slowLogger{StringLoggers: stringLogger})
+	require.NoError(t, err)
+	factory := NewMessageLoggerFactory(rawLogger, true, 0).SetBufferSize(2).SetLosslessPrinting(true)
+	logger, err := factory.Create(context.Background())
+	require.NoError(t, err)
+	messages := make([]*client.MessageObject, 20)
+	for i := range messages {
+		messages[i] = client.NewMessageObject(fmt.Sprintf("%v %d", faker.Sentence(), i))
+		logger.LogMessage(messages[i])
+	}
+	require.NoError(t, logger.Close())
+	content := stringLogger.GetLogContent()
+	for i := range messages {
+		assert.Contains(t, content, messages[i].Message)
+	}
+	statistics := getStatistics(t, logger)
+	assert.Zero(t, statistics.Dropped)
+	assert.Zero(t, statistics.Suppressed)
+	assert.Positive(t, statistics.Delayed)
+	assert.Contains(t, content, fmt.Sprintf("%d messages were delayed as the message buffer was full", statistics.Delayed))
+}
+
+func TestLosslessPrintingCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	rawLogger, err := logging.NewStandardClientLogger(faker.Word(), nil)
+	require.NoError(t, err)
+	printer := newBufferedMessagePrinter(ctx, newBasicSynchronousMessagePrinter(ctx, rawLogger), 1, true, &printingStatistics{})
+	_, err = printer.Write([]byte(faker.Sentence()))
+	require.NoError(t, err)
+	cancel()
+	_, err = printer.Write([]byte(faker.Sentence()))
+	errortest.AssertError(t, err, commonerrors.ErrCancelled)
+	require.NoError(t, printer.Close())
+	_, err = printer.Write([]byte(faker.Sentence()))
+	require.Error(t, err)
+}
+
+func TestLossyPrinting(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	stringLogger, err := logs.NewStringLogger(faker.Word())
+	require.NoError(t, err)
+	rawLogger, err := logging.NewClientLogger(faker.Word(), &slowLogger{StringLoggers: stringLogger})
+	require.NoError(t, err)
+	factory := NewMessageLoggerFactory(rawLogger, true, 0).SetBufferSize(1)
+	logger, err := factory.Create(context.Background())
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		logger.LogMessage(client.NewMessageObject(fmt.Sprintf("%v %d", faker.Sentence(), i)))
+	}
+	require.NoError(t, logger.Close())
+	content := stringLogger.GetLogContent()
+	statistics := getStatistics(t, logger)
+	assert.Positive(t, statistics.Dropped)
+	assert.Zero(t, statistics.Delayed)
+	assert.Zero(t, statistics.Suppressed)
+	// drops are only reported once, when the logger is closed.
+	assert.Equal(t, 1, strings.Count(content, "dropped"))
+	assert.Contains(t, content, fmt.Sprintf("%d messages were dropped as they could not be printed fast enough", statistics.Dropped))
+}
+
+func TestMessageLoggerFactoryBufferSize(t *testing.T) {
+	rawLogger, err := logging.NewStandardClientLogger(faker.Word(), nil)
+	require.NoError(t, err)
+	factory := NewMessageLoggerFactory(rawLogger, true, 0)
+	assert.Equal(t, DefaultMessageBufferSize, factory.GetBufferSize())
+	assert.Equal(t, 10, factory.SetBufferSize(10).GetBufferSize())
+	assert.Equal(t, DefaultMessageBufferSize, factory.SetBufferSize(-1).GetBufferSize())
+}
+
+func TestSynchronousMessageLoggerStatistics(t *testing.T) {
+	rawLogger, err := logging.NewStandardClientLogger(faker.Word(), nil)
+	require.NoError(t, err)
+	logger, err := NewBasicSynchronousMessageLogger(context.Background(), rawLogger)
+	require.NoError(t, err)
+	logger.LogMessage(client.NewMessageObject(faker.Sentence()))
+	require.NoError(t, logger.Close())
+	assert.Equal(t, MessageStatistics{}, getStatistics(t, logger))
+	assert.Empty(t, (&printingStatistics{}).summary())
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ARM-software/golang-utils/utils/field"
+)
+
+// Severity describes the severity of a message as stated by the services, from the least to the most severe.
+type Severity int
+
+const (
+	// SeverityDebug corresponds to messages only useful for debugging.
+	SeverityDebug Severity = iota
+	// SeverityInfo corresponds to informational messages.
+	SeverityInfo
+	// SeverityWarning corresponds to messages about potential problems.
+	SeverityWarning
+	// SeverityError corresponds to messages about errors.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// ParseSeverity determines the severity corresponding to a severity string as stated by the services e.g. `DEBUG` or `Warning`. Comparison is case-insensitive.
+// ok is false if the severity is not part of the services' vocabulary.
+func ParseSeverity(severity string) (s Severity, ok bool) {
+	ok = true
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "debug":
+		s = SeverityDebug
+	case "info", "information":
+		s = SeverityInfo
+	case "warning", "warn":
+		s = SeverityWarning
+	case "error":
+		s = SeverityError
+	default:
+		ok = false
+	}
+	return
+}
+
+// severityFilter determines which messages should be printed given a minimum severity and keeps track of those which are not.
+// Messages whose severity is unknown or missing are always printed so that no information is lost.
+type severityFilter struct {
+	mu         sync.Mutex
+	minimum    Severity
+	suppressed map[Severity]int
+}
+
+func newSeverityFilter(minimum Severity) *severityFilter {
+	return &severityFilter{
+		minimum:    minimum,
+		suppressed: map[Severity]int{},
+	}
+}
+
+// keep states whether a message should be printed. If not, it is counted as suppressed.
+func (f *severityFilter) keep(msg IMessage) bool {
+	if f == nil || msg == nil {
+		return true
+	}
+	severityStr, ok := msg.GetSeverityOk()
+	if !ok {
+		return true
+	}
+	severity, known := ParseSeverity(field.OptionalString(severityStr, ""))
+	if !known || severity >= f.minimum {
+		return true
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.suppressed[severity]++
+	return false
+}
+
+// suppressedCount returns the number of messages which were not printed.
+func (f *severityFilter) suppressedCount() (count int) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.suppressed {
+		count += c
+	}
+	return
+}
+
+// summary describes the messages which were not printed e.g. `12 messages below severity info were suppressed (debug: 12)` or returns an empty string if there were none.
+func (f *severityFilter) summary() string {
+	total := f.suppressedCount()
+	if total == 0 {
+		return ""
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var counts []string
+	for s := SeverityDebug; s < f.minimum; s++ {
+		if c := f.suppressed[s]; c > 0 {
+			counts = append(counts, fmt.Sprintf("%v: %d", s, c))
+		}
+	}
+	return fmt.Sprintf("%d messages below severity %v were suppressed (%v)", total, f.minimum, strings.Join(counts, ", "))
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package messages
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/logging"
+	"github.com/ARM-software/embedded-development-services-client/client"
+	"github.com/ARM-software/golang-utils/utils/logs"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		expected Severity
+		known    bool
+	}{
+		{severity: "debug", expected: SeverityDebug, known: true},
+		{severity: "DEBUG", expected: SeverityDebug, known: true},
+		{severity: "Info", expected: SeverityInfo, known: true},
+		{severity: " information ", expected: SeverityInfo, known: true},
+		{severity: "WARNING", expected: SeverityWarning, known: true},
+		{severity: "warn", expected: SeverityWarning, known: true},
+		{severity: "Error", expected: SeverityError, known: true},
+		{severity: "MAJOR", known: false},
+		{severity: "", known: false},
+	}
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.severity, func(t *testing.T) {
+			severity, ok := ParseSeverity(test.severity)
+			assert.Equal(t, test.known, ok)
+			if test.known {
+				assert.Equal(t, test.expected, severity)
+			}
+		})
+	}
+	assert.Equal(t, "warning", SeverityWarning.String())
+}
+
+func TestMinimumSeverity(t *testing.T) {
+	newMessage := func(severity string) *client.MessageObject {
+		message := client.NewMessageObject(faker.Sentence())
+		if severity != "" {
+			message.SetSeverity(severity)
+		}
+		return message
+	}
+	severities := []string{"debug", "DEBUG", "info", "Warning", "error", "MAJOR", "", "debug"}
+	tests := []struct {
+		name    string
+		factory func(logging.ILogger) *MessageLoggerFactory
+		printed []string
+		summary string
+	}{
+		{
+			name: "no filter",
+			factory: func(l logging.ILogger) *MessageLoggerFactory {
+				return NewMessageLoggerFactory(l, false, 0)
+			},
+			printed: severities,
+		},
+		{
+			name: "warning without summary",
+			factory: func(l logging.ILogger) *MessageLoggerFactory {
+				return NewMessageLoggerFactoryWithFormatter(l, false, 0, DefaultMessageFormatter(), WithMinimumSeverity(SeverityWarning))
+			},
+			printed: []string{"Warning", "error", "MAJOR", ""},
+		},
+		{
+			name: "info with summary",
+			factory: func(l logging.ILogger) *MessageLoggerFactory {
+				return NewMessageLoggerFactoryWithMinimumSeverity(l, true, 0, SeverityInfo)
+			},
+			printed: []string{"info", "Warning", "error", "MAJOR", ""},
+			summary: "3 messages below severity info were suppressed (debug: 3)",
+		},
+		{
+			name: "error with summary",
+			factory: func(l logging.ILogger) *MessageLoggerFactory {
+				return NewMessageLoggerFactoryWithMinimumSeverity(l, false, time.Nanosecond, SeverityError)
+			},
+			printed: []string{"error", "MAJOR", ""},
+			summary: "5 messages below severity error were suppressed (debug: 3, info: 1, warning: 1)",
+		},
+	}
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.name, func(t *testing.T) {
+			stringLogger, err := logs.NewStringLogger(faker.Word())
+			require.NoError(t, err)
+			rawLogger, err := logging.NewClientLogger(faker.Word(), stringLogger)
+			require.NoError(t, err)
+			logger, err := test.factory(rawLogger).Create(context.Background())
+			require.NoError(t, err)
+			messages := make([]*client.MessageObject, len(severities))
+			for j := range severities {
+				messages[j] = newMessage(severities[j])
+				logger.LogMessage(messages[j])
+			}
+			require.NoError(t, logger.Close())
+			content := stringLogger.GetLogContent()
+			printed := 0
+			for j := range messages {
+				if strings.Contains(content, messages[j].Message) {
+					printed++
+				}
+			}
+			assert.Equal(t, len(test.printed), printed)
+			assert.Equal(t, int64(len(severities)-len(test.printed)), getStatistics(t, logger).Suppressed)
+			// messages whose severity is unknown or missing are never suppressed.
+			assert.Contains(t, content, messages[5].Message)
+			assert.Contains(t, content, messages[6].Message)
+			if test.summary == "" {
+				assert.NotContains(t, content, "suppressed")
+			} else {
+				assert.Contains(t, content, test.summary)
+				assert.Greater(t, strings.Index(content, test.summary), strings.Index(content, messages[len(messages)-2].Message))
+			}
+		})
+	}
+}
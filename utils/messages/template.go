@@ -0,0 +1,161 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package messages
+
+import (
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/field"
+	"github.com/ARM-software/golang-utils/utils/reflection"
+)
+
+// DefaultMessageTemplate is a template laying messages out as the default message formatter does when all their fields are known (see DefaultMessageFormatter).
+const DefaultMessageTemplate = `[{{.Source}}] ({{formatTime .Ctime "2006-01-02 15:04:05.999999999 -0700 MST"}}) {{.Severity}} : {{.Message}}`
+
+// MessageTemplateData describes the fields of a message which can be used in a template (see NewTemplateMessageFormatter).
+type MessageTemplateData struct {
+	// Source is the source of the message or empty if unknown.
+	Source string
+	// Ctime is the creation time of the message or the zero time if unknown.
+	Ctime time.Time
+	// Severity is the severity of the message or empty if unknown.
+	Severity string
+	// Message is the content of the message.
+	Message string
+	// JobName is the name of the job the message relates to, if specified (see WithJobName).
+	JobName string
+	// JobType is the type of the job the message relates to, if specified (see WithJobType).
+	JobType string
+}
+
+// messageTemplate lays messages out according to a template. As relative times are determined from the first message formatted, it is stateful and shared by all copies of a formatter.
+type messageTemplate struct {
+	mu             sync.Mutex
+	template       *template.Template
+	firstCtime     time.Time
+	firstCtimeSeen bool
+}
+
+func newMessageTemplate(layout string) (t *messageTemplate, err error) {
+	if reflection.IsEmpty(layout) {
+		err = commonerrors.UndefinedVariable("message template")
+		return
+	}
+	t = &messageTemplate{}
+	tmpl, err := template.New("message").Funcs(template.FuncMap{
+		"formatTime": formatTime,
+		"sinceFirst": t.sinceFirst,
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"padRight":   padRight,
+		"padLeft":    padLeft,
+	}).Parse(layout)
+	if err != nil {
+		t = nil
+		err = commonerrors.WrapErrorf(commonerrors.ErrInvalid, err, "invalid message template [%v]", layout)
+		return
+	}
+	t.template = tmpl
+	return
+}
+
+func (t *messageTemplate) format(msg IMessage, options *FormatterOptions) (s string, err error) {
+	data := MessageTemplateData{
+		JobName: options.jobName,
+		JobType: options.jobType,
+	}
+	if source, ok := msg.GetSourceOk(); ok {
+		data.Source = field.OptionalString(source, "")
+	}
+	if ctime, ok := msg.GetCtimeOk(); ok && ctime != nil {
+		data.Ctime = *ctime
+		t.recordCtime(data.Ctime)
+	}
+	if severity, ok := msg.GetSeverityOk(); ok {
+		data.Severity = field.OptionalString(severity, "")
+	}
+	if message, ok := msg.GetMessageOk(); ok {
+		data.Message = field.OptionalString(message, "")
+	}
+	var b strings.Builder
+	err = t.template.Execute(&b, &data)
+	if err != nil {
+		err = commonerrors.WrapError(commonerrors.ErrUnexpected, err, "could not format message using template")
+		return
+	}
+	s = b.String()
+	return
+}
+
+// recordCtime records the creation time of the first message formatted, as the reference for relative times.
+func (t *messageTemplate) recordCtime(ctime time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.firstCtimeSeen {
+		t.firstCtime = ctime
+		t.firstCtimeSeen = true
+	}
+}
+
+// sinceFirst returns the time elapsed between the first message formatted and ctime e.g. `{{sinceFirst .Ctime}}`. It returns 0 if the time is unknown.
+func (t *messageTemplate) sinceFirst(ctime time.Time) time.Duration {
+	if ctime.IsZero() {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.firstCtimeSeen {
+		return 0
+	}
+	return ctime.Sub(t.firstCtime)
+}
+
+// formatTime formats a time according to a Go layout e.g. `{{formatTime .Ctime "15:04:05"}}`. It returns an empty string if the time is unknown.
+func formatTime(ctime time.Time, layout string) string {
+	if ctime.IsZero() {
+		return ""
+	}
+	return ctime.Format(layout)
+}
+
+// padRight pads s with spaces on the right so that it is at least width characters long e.g. `{{padRight 8 .Severity}}`.
+func padRight(width int, s string) string {
+	if padding := width - utf8.RuneCountInString(s); padding > 0 {
+		return s + strings.Repeat(" ", padding)
+	}
+	return s
+}
+
+// padLeft pads s with spaces on the left so that it is at least width characters long.
+func padLeft(width int, s string) string {
+	if padding := width - utf8.RuneCountInString(s); padding > 0 {
+		return strings.Repeat(" ", padding) + s
+	}
+	return s
+}
+
+// NewTemplateMessageFormatter returns a formatter which lays messages out according to a text/template layout e.g. `{{formatTime .Ctime "15:04:05"}} {{padRight 8 (upper .Severity)}} {{.Message}}`, so that the layout can be defined in configuration.
+// The template receives the fields of each message (see MessageTemplateData) and the following functions:
+//   - formatTime: formats a time according to a Go layout;
+//   - sinceFirst: returns the time elapsed since the first message formatted e.g. `+{{sinceFirst .Ctime}}`;
+//   - upper and lower: change the case of a string e.g. `{{upper .Severity}}`;
+//   - padRight and padLeft: pad a string with spaces up to a width e.g. `{{padLeft 10 .Source}}`.
+//
+// Only options specifying the job, if any, apply (see WithJobName and WithJobType).
+func NewTemplateMessageFormatter(layout string, option ...FormatterOption) (formatter *MessageFormatter, err error) {
+	tmpl, err := newMessageTemplate(layout)
+	if err != nil {
+		return
+	}
+	formatter = NewMessageFormatter(option...)
+	formatter.template = tmpl
+	return
+}
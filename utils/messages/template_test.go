@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package messages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/embedded-development-services-client/client"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+)
+
+func newTestMessage(text, source, severity string, ctime time.Time) *client.MessageObject {
+	message := client.NewMessageObject(text)
+	message.SetSource(source)
+	message.SetSeverity(severity)
+	message.SetCtime(ctime)
+	return message
+}
+
+func TestTemplateMessageFormatter(t *testing.T) {
+	start := time.Date(2025, time.March, 4, 10, 11, 12, 0, time.UTC)
+	text := faker.Sentence()
+	source := faker.Word()
+
+	t.Run("layout", func(t *testing.T) {
+		formatter, err := NewTemplateMessageFormatter(`{{formatTime .Ctime "15:04:05"}} +{{sinceFirst .Ctime}} {{padRight 6 (upper .Severity)}}|{{padLeft 4 .Source}}| {{.JobName}}/{{.JobType}}: {{.Message}}`, WithJobName("job"), WithJobType("build"))
+		require.NoError(t, err)
+		formatted, err := formatter.FormatMessage(newTestMessage(text, "src", "info", start))
+		require.NoError(t, err)
+		assert.Equal(t, "10:11:12 +0s INFO  | src| job/build: "+text, formatted)
+		formatted, err = formatter.FormatMessage(newTestMessage(text, "source", "major", start.Add(1500*time.Millisecond)))
+		require.NoError(t, err)
+		assert.Equal(t, "10:11:13 +1.5s MAJOR |source| job/build: "+text, formatted)
+	})
+	t.Run("relative time shared by copies", func(t *testing.T) {
+		formatter, err := NewTemplateMessageFormatter(`{{sinceFirst .Ctime}}`)
+		require.NoError(t, err)
+		formatted, err := formatter.FormatMessage(newTestMessage(text, source, "info", start))
+		require.NoError(t, err)
+		assert.Equal(t, "0s", formatted)
+		formatterCopy := *formatter
+		formatted, err = formatterCopy.FormatMessage(newTestMessage(text, source, "info", start.Add(time.Minute)))
+		require.NoError(t, err)
+		assert.Equal(t, "1m0s", formatted)
+	})
+	t.Run("unknown fields", func(t *testing.T) {
+		formatter, err := NewTemplateMessageFormatter(`[{{.Source}}]{{formatTime .Ctime "15:04"}}{{sinceFirst .Ctime}} {{.Message}}`)
+		require.NoError(t, err)
+		formatted, err := formatter.FormatMessage(client.NewMessageObject(text))
+		require.NoError(t, err)
+		assert.Equal(t, "[]0s "+text, formatted)
+	})
+	t.Run("default template", func(t *testing.T) {
+		formatter, err := NewTemplateMessageFormatter(DefaultMessageTemplate)
+		require.NoError(t, err)
+		message := newTestMessage(text, source, "MAJOR", start)
+		formatted, err := formatter.FormatMessage(message)
+		require.NoError(t, err)
+		expected, err := FormatMessage(message)
+		require.NoError(t, err)
+		assert.Equal(t, expected, formatted)
+	})
+	t.Run("invalid templates", func(t *testing.T) {
+		_, err := NewTemplateMessageFormatter("")
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+		_, err = NewTemplateMessageFormatter("{{.Message")
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+		_, err = NewTemplateMessageFormatter("{{unknown .Message}}")
+		errortest.AssertError(t, err, commonerrors.ErrInvalid)
+		formatter, err := NewTemplateMessageFormatter("{{.Unknown}}")
+		require.NoError(t, err)
+		_, err = formatter.FormatMessage(client.NewMessageObject(text))
+		errortest.AssertError(t, err, commonerrors.ErrUnexpected)
+		_, err = formatter.FormatMessage(nil)
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+	})
+}
@@ -16,6 +16,7 @@ package mocks
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
 	artefacts "github.com/ARM-software/embedded-development-services-client-utils/utils/artefacts"
@@ -80,6 +81,26 @@ func (mr *MockIArtefactManagerMockRecorder[M, D]) DownloadAllJobArtefactsWithOpt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAllJobArtefactsWithOptions", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).DownloadAllJobArtefactsWithOptions), varargs...)
 }
 
+// DownloadAllJobArtefactsWithReport mocks base method.
+func (m *MockIArtefactManager[M, D]) DownloadAllJobArtefactsWithReport(ctx context.Context, jobName, outputDirectory string, opts ...artefacts.DownloadOption) (*artefacts.DownloadReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobName, outputDirectory}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadAllJobArtefactsWithReport", varargs...)
+	ret0, _ := ret[0].(*artefacts.DownloadReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAllJobArtefactsWithReport indicates an expected call of DownloadAllJobArtefactsWithReport.
+func (mr *MockIArtefactManagerMockRecorder[M, D]) DownloadAllJobArtefactsWithReport(ctx, jobName, outputDirectory any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobName, outputDirectory}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAllJobArtefactsWithReport", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).DownloadAllJobArtefactsWithReport), varargs...)
+}
+
 // DownloadAllJobArtefactsWithTree mocks base method.
 func (m *MockIArtefactManager[M, D]) DownloadAllJobArtefactsWithTree(ctx context.Context, jobName string, maintainTreeStructure bool, outputDirectory string) error {
 	m.ctrl.T.Helper()
@@ -94,6 +115,26 @@ func (mr *MockIArtefactManagerMockRecorder[M, D]) DownloadAllJobArtefactsWithTre
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAllJobArtefactsWithTree", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).DownloadAllJobArtefactsWithTree), ctx, jobName, maintainTreeStructure, outputDirectory)
 }
 
+// DownloadArtefactsForJobs mocks base method.
+func (m *MockIArtefactManager[M, D]) DownloadArtefactsForJobs(ctx context.Context, jobNames []string, outputDirectory string, opts ...artefacts.DownloadOption) (*artefacts.JobsDownloadReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobNames, outputDirectory}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadArtefactsForJobs", varargs...)
+	ret0, _ := ret[0].(*artefacts.JobsDownloadReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadArtefactsForJobs indicates an expected call of DownloadArtefactsForJobs.
+func (mr *MockIArtefactManagerMockRecorder[M, D]) DownloadArtefactsForJobs(ctx, jobNames, outputDirectory any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobNames, outputDirectory}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadArtefactsForJobs", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).DownloadArtefactsForJobs), varargs...)
+}
+
 // DownloadJobArtefact mocks base method.
 func (m *MockIArtefactManager[M, D]) DownloadJobArtefact(ctx context.Context, jobName, outputDirectory string, artefactManager M) error {
 	m.ctrl.T.Helper()
@@ -150,6 +191,55 @@ func (mr *MockIArtefactManagerMockRecorder[M, D]) DownloadJobArtefactWithTree(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadJobArtefactWithTree", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).DownloadJobArtefactWithTree), ctx, jobName, maintainTreeLocation, outputDirectory, artefactManager)
 }
 
+// FollowJobArtefacts mocks base method.
+func (m *MockIArtefactManager[M, D]) FollowJobArtefacts(ctx context.Context, jobName, outputDirectory string, hasJobCompleted artefacts.HasJobCompletedFunc, opts ...artefacts.DownloadOption) (*artefacts.DownloadReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobName, outputDirectory, hasJobCompleted}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FollowJobArtefacts", varargs...)
+	ret0, _ := ret[0].(*artefacts.DownloadReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowJobArtefacts indicates an expected call of FollowJobArtefacts.
+func (mr *MockIArtefactManagerMockRecorder[M, D]) FollowJobArtefacts(ctx, jobName, outputDirectory, hasJobCompleted any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobName, outputDirectory, hasJobCompleted}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowJobArtefacts", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).FollowJobArtefacts), varargs...)
+}
+
+// IterateJobArtefacts mocks base method.
+func (m *MockIArtefactManager[M, D]) IterateJobArtefacts(ctx context.Context, jobName string) iter.Seq2[M, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateJobArtefacts", ctx, jobName)
+	ret0, _ := ret[0].(iter.Seq2[M, error])
+	return ret0
+}
+
+// IterateJobArtefacts indicates an expected call of IterateJobArtefacts.
+func (mr *MockIArtefactManagerMockRecorder[M, D]) IterateJobArtefacts(ctx, jobName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateJobArtefacts", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).IterateJobArtefacts), ctx, jobName)
+}
+
+// ListJobArtefactManagers mocks base method.
+func (m *MockIArtefactManager[M, D]) ListJobArtefactManagers(ctx context.Context, jobName string) ([]M, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobArtefactManagers", ctx, jobName)
+	ret0, _ := ret[0].([]M)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobArtefactManagers indicates an expected call of ListJobArtefactManagers.
+func (mr *MockIArtefactManagerMockRecorder[M, D]) ListJobArtefactManagers(ctx, jobName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobArtefactManagers", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).ListJobArtefactManagers), ctx, jobName)
+}
+
 // ListJobArtefacts mocks base method.
 func (m *MockIArtefactManager[M, D]) ListJobArtefacts(ctx context.Context, jobName string) (pagination.IPaginatorAndPageFetcher, error) {
 	m.ctrl.T.Helper()
@@ -164,3 +254,23 @@ func (mr *MockIArtefactManagerMockRecorder[M, D]) ListJobArtefacts(ctx, jobName
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobArtefacts", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).ListJobArtefacts), ctx, jobName)
 }
+
+// PlanJobArtefactsDownload mocks base method.
+func (m *MockIArtefactManager[M, D]) PlanJobArtefactsDownload(ctx context.Context, jobName, outputDirectory string, opts ...artefacts.DownloadOption) (*artefacts.DownloadPlan, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobName, outputDirectory}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PlanJobArtefactsDownload", varargs...)
+	ret0, _ := ret[0].(*artefacts.DownloadPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanJobArtefactsDownload indicates an expected call of PlanJobArtefactsDownload.
+func (mr *MockIArtefactManagerMockRecorder[M, D]) PlanJobArtefactsDownload(ctx, jobName, outputDirectory any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobName, outputDirectory}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanJobArtefactsDownload", reflect.TypeOf((*MockIArtefactManager[M, D])(nil).PlanJobArtefactsDownload), varargs...)
+}
@@ -4,11 +4,11 @@
  */
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ARM-software/embedded-development-services-client-utils/utils/messages (interfaces: IMessage,IMessageLogger)
+// Source: github.com/ARM-software/embedded-development-services-client-utils/utils/messages (interfaces: IMessage,IMessageLogger,IMessageLoggerWithStatistics)
 //
 // Generated by this command:
 //
-//	mockgen -destination=../mocks/mock_messages.go -package=mocks github.com/ARM-software/embedded-development-services-client-utils/utils/messages IMessage,IMessageLogger
+//	mockgen -destination=../mocks/mock_messages.go -package=mocks github.com/ARM-software/embedded-development-services-client-utils/utils/messages IMessage,IMessageLogger,IMessageLoggerWithStatistics
 //
 
 // Package mocks is a generated GoMock package.
@@ -269,3 +269,179 @@ func (mr *MockIMessageLoggerMockRecorder) SetLoggerSource(source any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoggerSource", reflect.TypeOf((*MockIMessageLogger)(nil).SetLoggerSource), source)
 }
+
+// MockIMessageLoggerWithStatistics is a mock of IMessageLoggerWithStatistics interface.
+type MockIMessageLoggerWithStatistics struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMessageLoggerWithStatisticsMockRecorder
+	isgomock struct{}
+}
+
+// MockIMessageLoggerWithStatisticsMockRecorder is the mock recorder for MockIMessageLoggerWithStatistics.
+type MockIMessageLoggerWithStatisticsMockRecorder struct {
+	mock *MockIMessageLoggerWithStatistics
+}
+
+// NewMockIMessageLoggerWithStatistics creates a new mock instance.
+func NewMockIMessageLoggerWithStatistics(ctrl *gomock.Controller) *MockIMessageLoggerWithStatistics {
+	mock := &MockIMessageLoggerWithStatistics{ctrl: ctrl}
+	mock.recorder = &MockIMessageLoggerWithStatisticsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMessageLoggerWithStatistics) EXPECT() *MockIMessageLoggerWithStatisticsMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIMessageLoggerWithStatistics) Check() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) Check() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).Check))
+}
+
+// Close mocks base method.
+func (m *MockIMessageLoggerWithStatistics) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).Close))
+}
+
+// GetStatistics mocks base method.
+func (m *MockIMessageLoggerWithStatistics) GetStatistics() messages.MessageStatistics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatistics")
+	ret0, _ := ret[0].(messages.MessageStatistics)
+	return ret0
+}
+
+// GetStatistics indicates an expected call of GetStatistics.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) GetStatistics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).GetStatistics))
+}
+
+// Log mocks base method.
+func (m *MockIMessageLoggerWithStatistics) Log(output ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range output {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Log", varargs...)
+}
+
+// Log indicates an expected call of Log.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) Log(output ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).Log), output...)
+}
+
+// LogEmptyMessageError mocks base method.
+func (m *MockIMessageLoggerWithStatistics) LogEmptyMessageError() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogEmptyMessageError")
+}
+
+// LogEmptyMessageError indicates an expected call of LogEmptyMessageError.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) LogEmptyMessageError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogEmptyMessageError", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).LogEmptyMessageError))
+}
+
+// LogError mocks base method.
+func (m *MockIMessageLoggerWithStatistics) LogError(err ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range err {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "LogError", varargs...)
+}
+
+// LogError indicates an expected call of LogError.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) LogError(err ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogError", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).LogError), err...)
+}
+
+// LogMarshallingError mocks base method.
+func (m *MockIMessageLoggerWithStatistics) LogMarshallingError(rawMessage *any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogMarshallingError", rawMessage)
+}
+
+// LogMarshallingError indicates an expected call of LogMarshallingError.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) LogMarshallingError(rawMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogMarshallingError", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).LogMarshallingError), rawMessage)
+}
+
+// LogMessage mocks base method.
+func (m *MockIMessageLoggerWithStatistics) LogMessage(msg messages.IMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogMessage", msg)
+}
+
+// LogMessage indicates an expected call of LogMessage.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) LogMessage(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogMessage", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).LogMessage), msg)
+}
+
+// LogMessagesCollection mocks base method.
+func (m *MockIMessageLoggerWithStatistics) LogMessagesCollection(ctx context.Context, messagePaginator pagination.IGenericPaginator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogMessagesCollection", ctx, messagePaginator)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogMessagesCollection indicates an expected call of LogMessagesCollection.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) LogMessagesCollection(ctx, messagePaginator any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogMessagesCollection", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).LogMessagesCollection), ctx, messagePaginator)
+}
+
+// SetLogSource mocks base method.
+func (m *MockIMessageLoggerWithStatistics) SetLogSource(source string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLogSource", source)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLogSource indicates an expected call of SetLogSource.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) SetLogSource(source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogSource", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).SetLogSource), source)
+}
+
+// SetLoggerSource mocks base method.
+func (m *MockIMessageLoggerWithStatistics) SetLoggerSource(source string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoggerSource", source)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoggerSource indicates an expected call of SetLoggerSource.
+func (mr *MockIMessageLoggerWithStatisticsMockRecorder) SetLoggerSource(source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoggerSource", reflect.TypeOf((*MockIMessageLoggerWithStatistics)(nil).SetLoggerSource), source)
+}
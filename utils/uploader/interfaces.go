@@ -0,0 +1,31 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package uploader provides utilities for sending input files e.g. project sources or workspaces, to the services.
+package uploader
+
+import (
+	"context"
+)
+
+// Mocks are generated using `go generate ./...`
+// Add interfaces to the following command for a mock to be generated
+//go:generate go tool mockgen -destination=../mocks/mock_$GOPACKAGE.go -package=mocks github.com/ARM-software/embedded-development-services-client-utils/utils/$GOPACKAGE IUploader
+
+type IUploader[I IUploadedItem] interface {
+	// ListFilesToUpload lists the files of a directory which would be uploaded given some upload options, along with their size and hash, without uploading anything.
+	ListFilesToUpload(ctx context.Context, directory string, opts ...UploadOption) (files []FileDescription, err error)
+	// UploadDirectory uploads all the files of a directory as items of parentName e.g. the inputs of a job, and returns a report describing what happened to each file.
+	// The tree structure of the directory is conveyed by the relative path of each file.
+	UploadDirectory(ctx context.Context, parentName string, directory string, opts ...UploadOption) (report *UploadReport, err error)
+}
+
+// IUploadedItem describes an item as known by the service e.g. an input file of a job.
+type IUploadedItem interface {
+	comparable
+	GetName() string
+	GetHashOk() (*string, bool)
+	GetSizeOk() (*int64, bool)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package uploader
+
+import (
+	"github.com/ARM-software/golang-utils/utils/logs"
+	"github.com/ARM-software/golang-utils/utils/retry"
+)
+
+// DefaultConcurrency is the default maximum number of files uploaded at the same time.
+const DefaultConcurrency = 4
+
+type UploadOptions struct {
+	StopOnFirstError bool
+	Logger           logs.Loggers
+	IgnorePatterns   []string
+	Concurrency      int
+	RetryPolicy      *retry.RetryPolicyConfiguration
+}
+
+type UploadOption func(*UploadOptions)
+
+func newDefaultUploadOptions() *UploadOptions {
+	return &UploadOptions{
+		StopOnFirstError: true,
+		Logger:           nil,
+		IgnorePatterns:   nil,
+		Concurrency:      DefaultConcurrency,
+		RetryPolicy:      retry.DefaultNoRetryPolicyConfiguration(),
+	}
+}
+
+func NewUploadOptions(opts ...UploadOption) (options *UploadOptions) {
+	options = newDefaultUploadOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return
+}
+
+// WithStopOnFirstError specifies whether the uploader will stop uploading files if it encounters an error from one of the files. Files which are then not uploaded are reported as cancelled rather than failed.
+func WithStopOnFirstError(stop bool) UploadOption {
+	return func(o *UploadOptions) {
+		o.StopOnFirstError = stop
+	}
+}
+
+// WithLogger specifies an optional logger that is used to log uploading files or errors encountered while uploading.
+func WithLogger(l logs.Loggers) UploadOption {
+	return func(o *UploadOptions) {
+		o.Logger = l
+	}
+}
+
+// WithIgnorePatterns specifies regular expressions of files or directories which should not be uploaded e.g. `\.git` or `.*\.o$`.
+// Patterns are matched against the slash-separated path of files relative to the directory uploaded, as for filesystem.ExcludeAll.
+func WithIgnorePatterns(patterns ...string) UploadOption {
+	return func(o *UploadOptions) {
+		o.IgnorePatterns = append(o.IgnorePatterns, patterns...)
+	}
+}
+
+// WithConcurrency specifies the maximum number of files uploaded at the same time. Files are uploaded one at a time if concurrency is not positive.
+func WithConcurrency(concurrency int) UploadOption {
+	return func(o *UploadOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// WithRetryPolicy specifies how a file should be uploaded again if its upload failed for a reason which may be transient e.g. an unavailable service or a hash reported by the service not matching the file's.
+// Each file is attempted at most policy.RetryMax times and backoff is performed between attempts as configured (see retry.DefaultExponentialBackoffRetryPolicyConfiguration).
+func WithRetryPolicy(policy *retry.RetryPolicyConfiguration) UploadOption {
+	return func(o *UploadOptions) {
+		o.RetryPolicy = policy
+	}
+}
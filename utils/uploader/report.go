@@ -0,0 +1,120 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package uploader
+
+import (
+	"time"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+)
+
+// UploadStatus describes the outcome of a file upload.
+type UploadStatus int
+
+const (
+	// UploadStatusFailed states that the file could not be uploaded.
+	UploadStatusFailed UploadStatus = iota
+	// UploadStatusUploaded states that the file was successfully uploaded and verified.
+	UploadStatusUploaded
+	// UploadStatusCancelled states that the upload of the file was not attempted or was interrupted because another file could not be uploaded (see WithStopOnFirstError).
+	UploadStatusCancelled
+)
+
+func (s UploadStatus) String() string {
+	switch s {
+	case UploadStatusUploaded:
+		return "uploaded"
+	case UploadStatusCancelled:
+		return "cancelled"
+	default:
+		return "failed"
+	}
+}
+
+// FileReport describes what happened to a particular file during an upload.
+type FileReport struct {
+	FileDescription
+	// Name is the name of the item holding the file on the service. It is empty if the item could not be created.
+	Name string
+	// Attempts is the number of times the file was sent. It is greater than 1 if the file had to be uploaded again (see WithRetryPolicy).
+	Attempts int
+	// Duration is the time it took to process the file.
+	Duration time.Duration
+	// Status is the outcome of the upload.
+	Status UploadStatus
+	// Err is the reason why the file could not be uploaded, if it failed. It is nil if the upload was cancelled.
+	Err error
+}
+
+// UploadReport describes the outcome of the upload of a directory.
+type UploadReport struct {
+	// Parent is the name of the entity the files were uploaded to e.g. a job.
+	Parent string
+	// Files lists what happened to each file, ordered by relative path.
+	Files []FileReport
+	// Uploaded is the number of files successfully uploaded.
+	Uploaded int
+	// Failed is the number of files which could not be uploaded.
+	Failed int
+	// Cancelled is the number of files which were not uploaded because another file could not be uploaded (see WithStopOnFirstError).
+	Cancelled int
+	// TotalSize is the total number of bytes uploaded.
+	TotalSize int64
+	// Duration is the time it took to process all the files.
+	Duration time.Duration
+}
+
+func newUploadReport(parentName string) *UploadReport {
+	return &UploadReport{Parent: parentName}
+}
+
+// tally determines the totals of the report from the outcome of each file.
+func (r *UploadReport) tally() {
+	r.Uploaded, r.Failed, r.Cancelled, r.TotalSize = 0, 0, 0, 0
+	for i := range r.Files {
+		switch r.Files[i].Status {
+		case UploadStatusUploaded:
+			r.Uploaded++
+			r.TotalSize += r.Files[i].Size
+		case UploadStatusCancelled:
+			r.Cancelled++
+		default:
+			r.Failed++
+		}
+	}
+}
+
+// HasFailures states whether some files could not be uploaded.
+func (r *UploadReport) HasFailures() bool {
+	return r != nil && r.Failed > 0
+}
+
+// FailedFiles returns the reports of all the files which could not be uploaded.
+func (r *UploadReport) FailedFiles() (failed []FileReport) {
+	if r == nil {
+		return
+	}
+	for i := range r.Files {
+		if r.Files[i].Status == UploadStatusFailed {
+			failed = append(failed, r.Files[i])
+		}
+	}
+	return
+}
+
+// Errors returns all the errors encountered during the upload joined together or nil if there were none.
+func (r *UploadReport) Errors() error {
+	var errs []error
+	for _, f := range r.FailedFiles() {
+		if f.Err != nil {
+			errs = append(errs, f.Err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return commonerrors.Join(errs...)
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package uploader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/api"
+	"github.com/ARM-software/embedded-development-services-client-utils/utils/internal/transfer"
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/hashing"
+	"github.com/ARM-software/golang-utils/utils/parallelisation"
+	"github.com/ARM-software/golang-utils/utils/reflection"
+)
+
+type (
+	// CreateItemFunc is a function which creates the item holding a file on the service e.g. an input file of a job.
+	CreateItemFunc[I IUploadedItem] = func(ctx context.Context, parentName string, file *FileDescription) (I, *http.Response, error)
+	// UploadItemContentFunc is a function which uploads the content of a file into an item previously created and returns the item as known by the service once the content was received.
+	UploadItemContentFunc[I IUploadedItem] = func(ctx context.Context, parentName string, item I, content io.Reader) (I, *http.Response, error)
+)
+
+// FileDescription describes a local file to upload.
+type FileDescription struct {
+	// Path is the path of the file on the local filesystem.
+	Path string
+	// RelativePath is the slash-separated path of the file relative to the directory uploaded.
+	RelativePath string
+	// Size is the size in bytes of the file.
+	Size int64
+	// Hash is the hexadecimal SHA-256 digest of the file content.
+	Hash string
+}
+
+type Uploader[I IUploadedItem] struct {
+	createItemFunc        CreateItemFunc[I]
+	uploadItemContentFunc UploadItemContentFunc[I]
+}
+
+// NewUploader returns an uploader relying on createItem to create the items holding files on the service and on uploadItemContent to send their content.
+func NewUploader[I IUploadedItem](createItem CreateItemFunc[I], uploadItemContent UploadItemContentFunc[I]) IUploader[I] {
+	return &Uploader[I]{
+		createItemFunc:        createItem,
+		uploadItemContentFunc: uploadItemContent,
+	}
+}
+
+func (u *Uploader[I]) ListFilesToUpload(ctx context.Context, directory string, opts ...UploadOption) (files []FileDescription, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	files, err = listFilesToUpload(ctx, directory, NewUploadOptions(opts...))
+	return
+}
+
+// listFilesToUpload walks directory and describes the regular files which are not ignored, ordered by relative path.
+func listFilesToUpload(ctx context.Context, directory string, options *UploadOptions) (files []FileDescription, err error) {
+	if reflection.IsEmpty(directory) {
+		err = commonerrors.UndefinedVariable("directory to upload")
+		return
+	}
+	fs := filesystem.GetGlobalFileSystem()
+	if !fs.Exists(directory) {
+		err = commonerrors.Newf(commonerrors.ErrNotFound, "directory to upload [%v] could not be found", directory)
+		return
+	}
+	ignored, err := filesystem.NewExclusionRegexList('/', options.IgnorePatterns...)
+	if err != nil {
+		return
+	}
+	// the directory is resolved so that the location of files can be checked once their own path is resolved.
+	resolvedDirectory, err := filesystem.EvalSymlinks(fs, directory)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not resolve directory to upload [%v]", directory)
+		return
+	}
+	paths, err := fs.LsRecursive(ctx, directory, false)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not list files to upload in [%v]", directory)
+		return
+	}
+	hasher, err := filesystem.NewFileHash(hashing.HashSha256)
+	if err != nil {
+		return
+	}
+	for i := range paths {
+		err = parallelisation.DetermineContextError(ctx)
+		if err != nil {
+			return
+		}
+		relativePath, subErr := filepath.Rel(directory, paths[i])
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, subErr, "could not determine the path of [%v] relative to [%v]", paths[i], directory)
+			return
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		// a leading separator lets patterns designating top-level directories match their content too.
+		if filesystem.IsPathExcluded("/"+relativePath, ignored...) {
+			continue
+		}
+		// links are not followed as they could point outside the directory uploaded.
+		info, subErr := fs.Lstat(paths[i])
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, subErr, "could not describe file [%v]", paths[i])
+			return
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		// files reached through a symbolic link to a directory are only uploaded if they are actually located within the directory uploaded.
+		resolvedPath, subErr := filesystem.EvalSymlinks(fs, paths[i])
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, subErr, "could not resolve file [%v]", paths[i])
+			return
+		}
+		if !transfer.IsWithinDirectory(resolvedDirectory, resolvedPath) {
+			if options.Logger != nil {
+				options.Logger.Log(fmt.Sprintf("%s is not uploaded as it is located outside %s", relativePath, directory))
+			}
+			continue
+		}
+		hash, subErr := hasher.CalculateFileWithContext(ctx, fs, paths[i])
+		if subErr != nil {
+			err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, subErr, "could not calculate hash of file [%v]", paths[i])
+			return
+		}
+		files = append(files, FileDescription{
+			Path:         paths[i],
+			RelativePath: relativePath,
+			Size:         info.Size(),
+			Hash:         hash,
+		})
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].RelativePath < files[j].RelativePath })
+	return
+}
+
+func (u *Uploader[I]) UploadDirectory(ctx context.Context, parentName string, directory string, opts ...UploadOption) (report *UploadReport, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	report = newUploadReport(parentName)
+	start := time.Now()
+	defer func() { report.Duration = time.Since(start) }()
+	if u.createItemFunc == nil || u.uploadItemContentFunc == nil {
+		err = commonerrors.New(commonerrors.ErrUndefined, "functions to upload items were not properly defined")
+		return
+	}
+	if reflection.IsEmpty(parentName) {
+		err = commonerrors.UndefinedVariable("name of the entity to upload files to")
+		return
+	}
+	options := NewUploadOptions(opts...)
+	files, err := listFilesToUpload(ctx, directory, options)
+	if err != nil {
+		return
+	}
+
+	report.Files = make([]FileReport, len(files))
+	group, gCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(options.Concurrency, 1))
+	for i := range files {
+		report.Files[i].FileDescription = files[i]
+		group.Go(func() error {
+			fileCtx := ctx
+			if options.StopOnFirstError {
+				fileCtx = gCtx
+			}
+			fileStart := time.Now()
+			subErr := u.uploadFile(fileCtx, parentName, options, &report.Files[i])
+			report.Files[i].Duration = time.Since(fileStart)
+			// files interrupted or not started because another file could not be uploaded did not fail on their own account.
+			if subErr != nil && options.StopOnFirstError && isCancelledByGroup(ctx, gCtx, subErr) {
+				report.Files[i].Status = UploadStatusCancelled
+				return nil
+			}
+			if subErr != nil {
+				report.Files[i].Status = UploadStatusFailed
+				report.Files[i].Err = subErr
+				if options.Logger != nil {
+					options.Logger.LogError(subErr)
+				}
+				if options.StopOnFirstError {
+					return subErr
+				}
+				return nil
+			}
+			report.Files[i].Status = UploadStatusUploaded
+			if options.Logger != nil {
+				options.Logger.Log(fmt.Sprintf("uploaded %s", files[i].RelativePath))
+			}
+			return nil
+		})
+	}
+	err = group.Wait()
+	report.tally()
+	if err == nil {
+		err = report.Errors()
+	}
+	return
+}
+
+// isCancelledByGroup states whether err results from the cancellation of the group context following the failure of another file rather than from the cancellation of ctx.
+func isCancelledByGroup(ctx, groupCtx context.Context, err error) bool {
+	if ctx.Err() != nil || groupCtx.Err() == nil {
+		return false
+	}
+	// the context error is wrapped into the cause of the cancellation i.e. the error of the file which failed first.
+	return commonerrors.Any(err, commonerrors.ErrCancelled, context.Canceled, context.Cause(groupCtx))
+}
+
+// uploadFile creates the item holding a file and sends its content according to the retry policy.
+func (u *Uploader[I]) uploadFile(ctx context.Context, parentName string, options *UploadOptions, result *FileReport) (err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	var item, none I
+	created := false
+	// transient is set by every attempt so that only failures which may not happen again are retried e.g. an unavailable service or a corrupted transfer.
+	transient := false
+	err = transfer.RetryIf(ctx, options.RetryPolicy, options.Logger, func() (subErr error) {
+		if !created {
+			item, subErr = api.GenericCallAndCheckSuccess[I](ctx, fmt.Sprintf("could not create item for file [%v]", result.RelativePath), func(fCtx context.Context) (I, *http.Response, error) {
+				return u.createItemFunc(fCtx, parentName, &result.FileDescription)
+			})
+			if subErr == nil && item == none {
+				subErr = commonerrors.Newf(commonerrors.ErrEmpty, "no item was created for file [%v]", result.RelativePath)
+			}
+			if subErr != nil {
+				transient = transfer.IsTransientError(subErr)
+				return
+			}
+			created = true
+			result.Name = item.GetName()
+		}
+		result.Attempts++
+		transient, subErr = u.attemptFileUpload(ctx, parentName, item, result)
+		return
+	}, fmt.Sprintf("Uploading file [%v] again...", result.RelativePath), func(error) bool {
+		return transient
+	})
+	return
+}
+
+// attemptFileUpload sends the content of a file and verifies what the service received.
+// transient states whether the failure, if any, may not happen if the file is sent again.
+func (u *Uploader[I]) attemptFileUpload(ctx context.Context, parentName string, item I, result *FileReport) (transient bool, err error) {
+	err = parallelisation.DetermineContextError(ctx)
+	if err != nil {
+		return
+	}
+	file, err := filesystem.GenericOpen(result.Path)
+	if err != nil {
+		err = commonerrors.WrapErrorf(commonerrors.ErrUnexpected, err, "could not open file [%v]", result.Path)
+		return
+	}
+	defer func() { _ = file.Close() }()
+	uploaded, err := api.GenericCallAndCheckSuccess[I](ctx, fmt.Sprintf("could not upload file [%v]", result.RelativePath), func(fCtx context.Context) (I, *http.Response, error) {
+		return u.uploadItemContentFunc(fCtx, parentName, item, file)
+	})
+	if err != nil {
+		transient = transfer.IsTransientError(err)
+		return
+	}
+	var none I
+	if uploaded == none {
+		err = commonerrors.Newf(commonerrors.ErrEmpty, "no item was returned once file [%v] was uploaded", result.RelativePath)
+		return
+	}
+	if size, ok := uploaded.GetSizeOk(); ok && size != nil && *size != result.Size {
+		transient = true
+		err = commonerrors.Newf(commonerrors.ErrCondition, "file [%v] size '%v' reported by the service does not match expected '%v'", result.RelativePath, *size, result.Size)
+		return
+	}
+	hash, ok := uploaded.GetHashOk()
+	if !ok || hash == nil {
+		err = commonerrors.Newf(commonerrors.ErrUndefined, "could not fetch the hash of file [%v] from the service", result.RelativePath)
+		return
+	}
+	reportedHash, err := transfer.ParseHash(*hash)
+	if err != nil {
+		err = commonerrors.DescribeCircumstanceAndKeepTypef(err, "could not verify the hash of file [%v] reported by the service", result.RelativePath)
+		return
+	}
+	if reportedHash.Algorithm != hashing.HashSha256 {
+		err = commonerrors.Newf(commonerrors.ErrUnsupported, "file [%v] hash reported by the service uses %v rather than %v", result.RelativePath, reportedHash.Algorithm, hashing.HashSha256)
+		return
+	}
+	if !reportedHash.Matches(result.Hash) {
+		transient = true
+		err = commonerrors.Newf(commonerrors.ErrCondition, "file [%v] hash '%v' reported by the service does not match expected '%v'", result.RelativePath, *hash, result.Hash)
+	}
+	return
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright (C) 2020-2025 Arm Limited or its affiliates and Contributors. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package uploader
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ARM-software/golang-utils/utils/commonerrors"
+	"github.com/ARM-software/golang-utils/utils/commonerrors/errortest"
+	"github.com/ARM-software/golang-utils/utils/field"
+	"github.com/ARM-software/golang-utils/utils/filesystem"
+	"github.com/ARM-software/golang-utils/utils/hashing"
+	"github.com/ARM-software/golang-utils/utils/retry"
+)
+
+type testItem struct {
+	name string
+	hash *string
+	size *int64
+}
+
+func (i *testItem) GetName() string {
+	return i.name
+}
+
+func (i *testItem) GetHashOk() (*string, bool) {
+	return i.hash, i.hash != nil
+}
+
+func (i *testItem) GetSizeOk() (*int64, bool) {
+	return i.size, i.size != nil
+}
+
+// testService stores the content of the files uploaded and lets tests alter what it reports.
+type testService struct {
+	mutex    sync.Mutex
+	contents map[string][]byte
+	// corrupt is the number of uploads for which the service reports a wrong hash.
+	corrupt atomic.Int32
+	// unavailable is the number of uploads which fail because the service is not available.
+	unavailable atomic.Int32
+	// hashAlgorithm is the algorithm of the hashes reported, SHA-256 by default.
+	hashAlgorithm string
+	active        atomic.Int32
+	maxActive     atomic.Int32
+}
+
+func newTestService() *testService {
+	return &testService{contents: map[string][]byte{}}
+}
+
+func (s *testService) createItem(ctx context.Context, parentName string, file *FileDescription) (*testItem, *http.Response, error) {
+	return &testItem{name: parentName + "/" + file.RelativePath}, &http.Response{StatusCode: http.StatusCreated}, nil
+}
+
+func (s *testService) uploadItemContent(ctx context.Context, parentName string, item *testItem, content io.Reader) (*testItem, *http.Response, error) {
+	active := s.active.Add(1)
+	defer s.active.Add(-1)
+	for {
+		current := s.maxActive.Load()
+		if active <= current || s.maxActive.CompareAndSwap(current, active) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	if s.unavailable.Add(-1) >= 0 {
+		return nil, &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(&emptyReader{})}, commonerrors.ErrUnavailable
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.corrupt.Add(-1) >= 0 {
+		data = append(data, '!')
+	}
+	algorithm := hashing.HashSha256
+	if s.hashAlgorithm != "" {
+		algorithm = s.hashAlgorithm
+	}
+	hash := strings.ToLower(algorithm) + ":" + hashing.CalculateHash(string(data), algorithm)
+	s.mutex.Lock()
+	s.contents[item.name] = data
+	s.mutex.Unlock()
+	return &testItem{name: item.name, hash: field.ToOptionalString(hash), size: field.ToOptionalInt64(int64(len(data)))}, &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+type emptyReader struct{}
+
+func (r *emptyReader) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+func newTestDirectory(t *testing.T) (directory string, files map[string]string) {
+	t.Helper()
+	directory = t.TempDir()
+	files = map[string]string{
+		"main.c":               faker.Paragraph(),
+		"include/main.h":       faker.Paragraph(),
+		"src/lib/library.c":    faker.Paragraph(),
+		"build/main.o":         faker.Paragraph(),
+		".git/config":          faker.Paragraph(),
+		"src/lib/library.o":    faker.Paragraph(),
+		"docs/README.md":       faker.Paragraph(),
+		"docs/images/logo.txt": faker.Word(),
+	}
+	for relativePath, content := range files {
+		path := filepath.Join(directory, filepath.FromSlash(relativePath))
+		require.NoError(t, filesystem.MkDir(filepath.Dir(path)))
+		require.NoError(t, filesystem.WriteFile(path, []byte(content), 0600))
+	}
+	return
+}
+
+func TestListFilesToUpload(t *testing.T) {
+	directory, files := newTestDirectory(t)
+	service := newTestService()
+	u := NewUploader[*testItem](service.createItem, service.uploadItemContent)
+
+	t.Run("all files", func(t *testing.T) {
+		listed, err := u.ListFilesToUpload(context.Background(), directory)
+		require.NoError(t, err)
+		require.Len(t, listed, len(files))
+		for i := range listed {
+			content, ok := files[listed[i].RelativePath]
+			require.True(t, ok)
+			assert.Equal(t, int64(len(content)), listed[i].Size)
+			assert.Equal(t, hashing.CalculateHash(content, hashing.HashSha256), listed[i].Hash)
+			assert.Equal(t, filepath.Join(directory, filepath.FromSlash(listed[i].RelativePath)), listed[i].Path)
+			if i > 0 {
+				assert.Less(t, listed[i-1].RelativePath, listed[i].RelativePath)
+			}
+		}
+	})
+	t.Run("ignored files", func(t *testing.T) {
+		listed, err := u.ListFilesToUpload(context.Background(), directory, WithIgnorePatterns(`/\.git`, `.*\.o$`), WithIgnorePatterns(`^/docs/images`))
+		require.NoError(t, err)
+		var relativePaths []string
+		for i := range listed {
+			relativePaths = append(relativePaths, listed[i].RelativePath)
+		}
+		assert.Equal(t, []string{"docs/README.md", "include/main.h", "main.c", "src/lib/library.c"}, relativePaths)
+	})
+	t.Run("linked directory outside", func(t *testing.T) {
+		outside, _ := newTestDirectory(t)
+		linked, _ := newTestDirectory(t)
+		require.NoError(t, os.Symlink(outside, filepath.Join(linked, "outside")))
+		listed, err := u.ListFilesToUpload(context.Background(), linked)
+		require.NoError(t, err)
+		require.Len(t, listed, len(files))
+		for i := range listed {
+			assert.NotContains(t, listed[i].RelativePath, "outside")
+		}
+	})
+	t.Run("directory reached through a link", func(t *testing.T) {
+		link := filepath.Join(t.TempDir(), "link")
+		require.NoError(t, os.Symlink(directory, link))
+		listed, err := u.ListFilesToUpload(context.Background(), filepath.Join(link, "src"), WithIgnorePatterns(`.*\.o$`))
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "lib/library.c", listed[0].RelativePath)
+	})
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := u.ListFilesToUpload(context.Background(), directory, WithIgnorePatterns(`(`))
+		require.Error(t, err)
+	})
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := u.ListFilesToUpload(context.Background(), filepath.Join(directory, faker.Word()+"-missing"))
+		errortest.AssertError(t, err, commonerrors.ErrNotFound)
+		_, err = u.ListFilesToUpload(context.Background(), "")
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := u.ListFilesToUpload(ctx, directory)
+		errortest.AssertError(t, err, commonerrors.ErrCancelled)
+	})
+}
+
+func TestUploadDirectory(t *testing.T) {
+	directory, files := newTestDirectory(t)
+	parent := faker.Word()
+
+	t.Run("upload", func(t *testing.T) {
+		service := newTestService()
+		u := NewUploader[*testItem](service.createItem, service.uploadItemContent)
+		report, err := u.UploadDirectory(context.Background(), parent, directory, WithConcurrency(2))
+		require.NoError(t, err)
+		require.NotNil(t, report)
+		assert.Equal(t, parent, report.Parent)
+		assert.Equal(t, len(files), report.Uploaded)
+		assert.Zero(t, report.Failed)
+		assert.False(t, report.HasFailures())
+		assert.NoError(t, report.Errors())
+		var totalSize int64
+		for relativePath, content := range files {
+			assert.Equal(t, []byte(content), service.contents[parent+"/"+relativePath])
+			totalSize += int64(len(content))
+		}
+		assert.Equal(t, totalSize, report.TotalSize)
+		for i := range report.Files {
+			assert.Equal(t, UploadStatusUploaded, report.Files[i].Status)
+			assert.Equal(t, 1, report.Files[i].Attempts)
+			assert.Equal(t, parent+"/"+report.Files[i].RelativePath, report.Files[i].Name)
+		}
+		assert.LessOrEqual(t, service.maxActive.Load(), int32(2))
+		assert.Positive(t, service.maxActive.Load())
+	})
+	t.Run("sequential", func(t *testing.T) {
+		service := newTestService()
+		u := NewUploader[*testItem](service.createItem, service.uploadItemContent)
+		report, err := u.UploadDirectory(context.Background(), parent, directory, WithConcurrency(0), WithIgnorePatterns(`.*\.o$`))
+		require.NoError(t, err)
+		assert.Equal(t, len(files)-2, report.Uploaded)
+		assert.Equal(t, int32(1), service.maxActive.Load())
+	})
+	t.Run("hash mismatch", func(t *testing.T) {
+		service := newTestService()
+		service.corrupt.Store(1)
+		u := NewUploader[*testItem](service.createItem, service.uploadItemContent)
+		report, err := u.UploadDirectory(context.Background(), parent, directory, WithStopOnFirstError(false))
+		errortest.AssertError(t, err, commonerrors.ErrCondition)
+		require.NotNil(t, report)
+		assert.True(t, report.HasFailures())
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, len(files)-1, report.Uploaded)
+		failed := report.FailedFiles()
+		require.Len(t, failed, 1)
+		assert.Equal(t, UploadStatusFailed, failed[0].Status)
+		errortest.AssertError(t, failed[0].Err, commonerrors.ErrCondition)
+	})
+	t.Run("unsupported hash algorithm", func(t *testing.T) {
+		service := newTestService()
+		service.hashAlgorithm = hashing.HashMd5
+		u := NewUploader[*testItem](service.createItem, service.uploadItemContent)
+		report, err := u.UploadDirectory(context.Background(), parent, directory, WithStopOnFirstError(false), WithRetryPolicy(retry.DefaultBasicRetryPolicyConfiguration()))
+		errortest.AssertError(t, err, commonerrors.ErrUnsupported)
+		assert.Equal(t, len(files), report.Failed)
+		for i := range report.Files {
+			assert.Equal(t, 1, report.Files[i].Attempts)
+		}
+	})
+	t.Run("retry", func(t *testing.T) {
+		service := newTestService()
+		service.corrupt.Store(1)
+		service.unavailable.Store(1)
+		u := NewUploader[*testItem](service.createItem, service.uploadItemContent)
+		policy := retry.DefaultBasicRetryPolicyConfiguration()
+		policy.RetryWaitMin = time.Millisecond
+		policy.RetryWaitMax = time.Millisecond
+		policy.RetryMax = 3
+		report, err := u.UploadDirectory(context.Background(), parent, directory, WithConcurrency(1), WithRetryPolicy(policy))
+		require.NoError(t, err)
+		assert.Equal(t, len(files), report.Uploaded)
+		// the first file was unavailable and then corrupted before being uploaded properly.
+		assert.Equal(t, 3, report.Files[0].Attempts)
+		for i := 1; i < len(report.Files); i++ {
+			assert.Equal(t, 1, report.Files[i].Attempts)
+		}
+	})
+	t.Run("stop on first error", func(t *testing.T) {
+		service := newTestService()
+		service.unavailable.Store(1)
+		u := NewUploader[*testItem](service.createItem, service.uploadItemContent)
+		report, err := u.UploadDirectory(context.Background(), parent, directory, WithConcurrency(1))
+		require.Error(t, err)
+		require.NotNil(t, report)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, len(files)-1, report.Cancelled)
+		assert.Zero(t, report.Uploaded)
+		require.Len(t, report.FailedFiles(), 1)
+		assert.Equal(t, UploadStatusFailed, report.Files[0].Status)
+		for i := 1; i < len(report.Files); i++ {
+			assert.Equal(t, UploadStatusCancelled, report.Files[i].Status)
+			assert.Equal(t, "cancelled", report.Files[i].Status.String())
+			assert.NoError(t, report.Files[i].Err)
+		}
+	})
+	t.Run("invalid uploader", func(t *testing.T) {
+		u := NewUploader[*testItem](nil, nil)
+		_, err := u.UploadDirectory(context.Background(), parent, directory)
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+		service := newTestService()
+		u = NewUploader[*testItem](service.createItem, service.uploadItemContent)
+		_, err = u.UploadDirectory(context.Background(), "", directory)
+		errortest.AssertError(t, err, commonerrors.ErrUndefined)
+	})
+}